@@ -7,14 +7,18 @@ import (
 )
 
 func main() {
-	borderMy := fmttab.Border(100)
-	fmttab.Borders[borderMy] = map[fmttab.BorderKind]string{
-
-		fmttab.BKVertical:         "|",
-		fmttab.BKHorizontal:       "-",
-		fmttab.BKHorizontalBorder: "_",
+	err := fmttab.RegisterBorder("my", fmttab.BorderStyle{
+		Vertical:         "|",
+		Horizontal:       "-",
+		HorizontalBorder: "_",
+	})
+	if err != nil {
+		panic(err)
+	}
+	tab := fmttab.New("Custom table:", fmttab.BorderNone, nil)
+	if err := tab.SetBorderName("my"); err != nil {
+		panic(err)
 	}
-	tab := fmttab.New("Custom table:", borderMy, nil)
 	tab.AddColumn("Key", 15, fmttab.AlignLeft).
 		AddColumn("Value", 15, fmttab.AlignRight)
 	tab.Data = []map[string]interface{}{
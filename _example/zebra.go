@@ -0,0 +1,32 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/arteev/fmttab"
+	"github.com/arteev/fmttab/style"
+)
+
+func main() {
+	envs := os.Environ()
+	i := 0
+	tab := fmttab.New("Environments", fmttab.BorderDouble, func() (bool, map[string]interface{}) {
+		if i >= len(envs) {
+			return false, nil
+		}
+		keyval := strings.SplitN(envs[i], "=", 2)
+		i++
+		return true, map[string]interface{}{
+			"ENV":   keyval[0],
+			"VALUE": keyval[1],
+		}
+	})
+	tab.AddColumn("ENV", 25, fmttab.AlignLeft).
+		AddColumn("VALUE", 25, fmttab.AlignLeft)
+	tab.Zebra = style.Style{BG: style.BrightBlack}
+	if !style.ModeAuto.Enabled(os.Stdout) {
+		tab.ZebraMarker = "▌"
+	}
+	tab.WriteTo(os.Stdout)
+}
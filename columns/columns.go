@@ -3,24 +3,74 @@ package columns
 import (
 	"errors"
 	"strconv"
+	"strings"
+
+	"github.com/arteev/fmttab/style"
+	"github.com/arteev/fmttab/textwidth"
 )
 
 const (
 	//WidthAuto auto sizing of width column
 	WidthAuto = 0
+)
+
+//Alignments
+const (
+	//AlignDefault align text along the left edge, the caption of column is aligned as the data
+	AlignDefault Align = iota
 	//AlignLeft align text along the left edge
-	AlignLeft = Align(false)
+	AlignLeft
 	//AlignRight align text along the right edge
-	AlignRight = Align(true)
+	AlignRight
+	//AlignCenter align text on the center of column
+	AlignCenter
+	//AlignJustify stretch text to the width of column by spaces between words
+	AlignJustify
+	//AlignDecimal align numbers on the decimal point
+	AlignDecimal
 )
 
 //Errors
 var (
 	ErrorAlreadyExists = errors.New("Column already exists")
+	ErrorInvalidWidth  = errors.New("Invalid width of column")
+	ErrorEmptyName     = errors.New("Empty name of column")
+	ErrorNotFound      = errors.New("Column not found")
+	ErrorNilColumn     = errors.New("Column is nil")
 )
 
-//A Align text alignment in column of the table
-type Align bool
+//A Align text alignment in column of the table. Align was bool before the alignments other than
+//left and right were added, so Align(true) is no longer AlignRight; use the constants.
+//The zero value AlignDefault aligns the data as AlignLeft
+type Align int
+
+//A Truncate the place where the text is cut if it does not fit into the column
+type Truncate int
+
+//Truncation modes
+const (
+	//TruncateEnd cuts the end of the text
+	TruncateEnd Truncate = iota
+	//TruncateStart cuts the beginning of the text
+	TruncateStart
+	//TruncateMiddle cuts the middle of the text
+	TruncateMiddle
+)
+
+//A Wrap mode of splitting the text of a cell into several lines
+type Wrap int
+
+//Wrap modes
+const (
+	//WrapNone the cell is always one line, line breaks are replaced by spaces
+	WrapNone Wrap = iota
+	//WrapHard the text is cut into lines by the width of the column
+	WrapHard
+	//WrapWord the text is split into lines between words
+	WrapWord
+	//WrapNewline the text is split into lines only by line breaks
+	WrapNewline
+)
 
 //A Column type of table columns
 type Column struct {
@@ -30,6 +80,35 @@ type Column struct {
 	Width   int
 	Aling   Align
 	Visible bool
+	//CaptionAlign alignment of the caption, AlignDefault means the alignment of data
+	CaptionAlign Align
+	//DecimalLen the width of the fractional part of numbers for AlignDecimal, computed by the table
+	DecimalLen int
+	//UnitLen the width of the units of numbers for AlignDecimal, computed by the table.
+	//The units are written after the fractional part
+	UnitLen int
+	//Number formatting of numbers, nil means the default formatting of values
+	Number *NumberFormat
+	//Formatter returns the text of values of the column, nil means the built-in renderers
+	Formatter Formatter
+	//Ellipsis marks the truncated text. If empty, the default of the table is used
+	Ellipsis string
+	//Truncate the place of the text where it is cut
+	Truncate Truncate
+	//Wrap mode of multi-line cells
+	Wrap Wrap
+	//MaxLines limits the count of lines of a cell, zero means no limit.
+	//The last line of a cell exceeding the limit is marked with ellipsis
+	MaxLines int
+	//Style the style of the cells of the column
+	Style style.Style
+	//CaptionStyle the style of the caption of the column
+	CaptionStyle style.Style
+	//Compare compares the values of the column for sorting, nil means the comparison by types of values
+	Compare func(a, b interface{}) int
+	//MergeRepeated suppresses the consecutive repeated values of the column,
+	//the cells with the repeated value are drawn as one cell spanning the rows
+	MergeRepeated bool
 }
 
 //A Columns array of the columns
@@ -62,9 +141,6 @@ func (c *Columns) FindByName(name string) *Column {
 
 //NewColumn append new column in list with check by name of column
 func (c *Columns) NewColumn(name, caption string, width int, aling Align) (*Column, error) {
-	if c.FindByName(name) != nil {
-		return nil, ErrorAlreadyExists
-	}
 	column := &Column{
 		Name:    name,
 		Caption: caption,
@@ -72,12 +148,35 @@ func (c *Columns) NewColumn(name, caption string, width int, aling Align) (*Colu
 		Aling:   aling,
 		Visible: true,
 	}
+	if err := column.Validate(); err != nil {
+		return nil, err
+	}
+	if c.FindByName(name) != nil {
+		return nil, ErrorAlreadyExists
+	}
 	c.columns = append(c.columns, column)
 	return column, nil
 }
 
+//Validate checks the name and the width of column
+func (c *Column) Validate() error {
+	if c == nil {
+		return ErrorNilColumn
+	}
+	if c.Name == "" {
+		return ErrorEmptyName
+	}
+	if c.Width < 0 {
+		return ErrorInvalidWidth
+	}
+	return nil
+}
+
 //Add append column with check exists
 func (c *Columns) Add(col *Column) error {
+	if err := col.Validate(); err != nil {
+		return err
+	}
 	for i := range c.columns {
 		if c.columns[i] == col {
 			return ErrorAlreadyExists
@@ -135,10 +234,186 @@ func (c *Column) GetWidth() int {
 
 }
 
-//GetMaskFormat returns a pattern string for formatting text in table column alignment
+//GetMaskFormat returns a pattern string for formatting text in table column alignment.
+//The fmt package pads by the number of runes, use Pad for text with wide characters
 func (c *Column) GetMaskFormat() string {
-	if c.Aling == AlignLeft {
-		return "%-" + strconv.Itoa(c.GetWidth()) + "v"
+	switch c.Aling {
+	case AlignRight, AlignDecimal:
+		return "%" + strconv.Itoa(c.GetWidth()) + "v"
+	}
+	return "%-" + strconv.Itoa(c.GetWidth()) + "v"
+}
+
+//Pad aligns the text in the column according to its display width
+func (c *Column) Pad(s string) string {
+	return c.pad(s, c.Aling, c.GetWidth())
+}
+
+//PadWidth aligns the text as Pad in the cell of width, the cell spans several columns
+func (c *Column) PadWidth(s string, width int) string {
+	return c.pad(s, c.Aling, width)
+}
+
+//PadCaption aligns the caption text in the column according to CaptionAlign
+func (c *Column) PadCaption(s string) string {
+	return c.pad(s, c.captionAlign(), c.GetWidth())
+}
+
+//PadCaptionWidth aligns the caption text as PadCaption in the cell of width, the cell spans several columns
+func (c *Column) PadCaptionWidth(s string, width int) string {
+	return c.pad(s, c.captionAlign(), width)
+}
+
+func (c *Column) captionAlign() Align {
+	align := c.CaptionAlign
+	if align == AlignDefault {
+		align = c.Aling
+		if align == AlignDecimal {
+			align = AlignRight
+		}
+	}
+	return align
+}
+
+func (c *Column) pad(s string, align Align, width int) string {
+	switch align {
+	case AlignRight:
+		return textwidth.PadLeft(s, width)
+	case AlignCenter:
+		n := width - textwidth.String(s)
+		if n <= 0 {
+			return s
+		}
+		return strings.Repeat(" ", n/2) + s + strings.Repeat(" ", n-n/2)
+	case AlignJustify:
+		return justify(s, width)
+	case AlignDecimal:
+		return c.padDecimal(s, width)
+	}
+	return textwidth.PadRight(s, width)
+}
+
+//padDecimal aligns the number so that the decimal points of the column are one under another
+func (c *Column) padDecimal(s string, width int) string {
+	intpart, frac, unit, ok := c.splitDecimal(s)
+	if !ok {
+		intpart, frac, unit = s, "", ""
+	}
+	fracwidth := 0
+	if c.DecimalLen > 0 {
+		fracwidth = c.DecimalLen + 1
+	}
+	if textwidth.String(frac) > fracwidth || textwidth.String(unit) > c.UnitLen ||
+		textwidth.String(intpart)+fracwidth+c.UnitLen > width {
+		return textwidth.PadLeft(s, width)
+	}
+	return textwidth.PadLeft(intpart, width-fracwidth-c.UnitLen) + textwidth.PadRight(frac, fracwidth) + textwidth.PadRight(unit, c.UnitLen)
+}
+
+//justify stretches the text to the width by spaces between words
+func justify(s string, width int) string {
+	words := strings.Fields(s)
+	if len(words) < 2 {
+		return textwidth.PadRight(s, width)
+	}
+	n := width
+	for _, w := range words {
+		n -= textwidth.String(w)
+	}
+	gaps := len(words) - 1
+	if n < gaps {
+		return textwidth.PadRight(s, width)
+	}
+	res := words[0]
+	for i, w := range words[1:] {
+		spaces := n / gaps
+		if i < n%gaps {
+			spaces++
+		}
+		res += strings.Repeat(" ", spaces) + w
+	}
+	return res
+}
+
+//Fraction returns the width of the fractional part of the number without the decimal separator
+//and whether the number has the decimal separator
+func (c *Column) Fraction(s string) (int, bool) {
+	_, frac, _, ok := c.splitDecimal(s)
+	if !ok || frac == "" {
+		return 0, false
+	}
+	return textwidth.String(frac) - textwidth.String(c.decimalSeparator()), true
+}
+
+//Unit returns the width of the units of the number, zero if the number has no units
+func (c *Column) Unit(s string) int {
+	_, _, unit, _ := c.splitDecimal(s)
+	return textwidth.String(unit)
+}
+
+//splitDecimal splits the number into the integer part, the fractional part with the decimal separator
+//and the units of Number. It returns false if the text is not a number,
+//the text without digits or with other characters is not split
+func (c *Column) splitDecimal(s string) (string, string, string, bool) {
+	unit := ""
+	if c.Number != nil {
+		unit = c.Number.unit(s)
+	}
+	number := s[:len(s)-len(unit)]
+	intpart, frac := number, ""
+	if i := strings.LastIndex(number, c.decimalSeparator()); i >= 0 {
+		intpart, frac = number[:i], number[i:]
+	}
+	if !isDigits(strings.TrimPrefix(frac, c.decimalSeparator()), "") {
+		return "", "", "", false
+	}
+	if len(intpart) > 0 && (intpart[0] == '-' || intpart[0] == '+') {
+		intpart = intpart[1:]
+	}
+	thousands := ""
+	if c.Number != nil {
+		thousands = c.Number.Locale.Thousands
+	}
+	if intpart == "" || !isDigits(intpart, thousands) {
+		return "", "", "", false
+	}
+	return number[:len(number)-len(frac)], frac, unit, true
+}
+
+//isDigits reports whether s consists of the digits and the separators of thousands
+func isDigits(s, thousands string) bool {
+	for s != "" {
+		switch {
+		case s[0] >= '0' && s[0] <= '9':
+			s = s[1:]
+		case thousands != "" && strings.HasPrefix(s, thousands):
+			s = s[len(thousands):]
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Column) decimalSeparator() string {
+	if c.Number != nil {
+		return c.Number.DecimalSeparator()
+	}
+	return "."
+}
+
+//Format returns the text of the value of a cell using Formatter, Number or the built-in renderers
+func (c *Column) Format(v interface{}) string {
+	if sv, ok := v.(style.Value); ok {
+		v = sv.Value
+	}
+	if c.Formatter != nil {
+		return c.Formatter(v)
+	}
+	if c.Number != nil {
+		if s, ok := c.Number.Format(v); ok {
+			return s
+		}
 	}
-	return "%" + strconv.Itoa(c.GetWidth()) + "v"
+	return FormatValue(v)
 }
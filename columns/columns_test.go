@@ -188,3 +188,80 @@ func TestGetColumns(t *testing.T) {
 		}
 	}
 }
+
+func TestPad(t *testing.T) {
+	var columns Columns
+	col, err := columns.NewColumn("Col1", "Columns 1", 6, AlignLeft)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := col.Pad("日本"); got != "日本  " {
+		t.Errorf("Expected %q,got %q", "日本  ", got)
+	}
+	col.Aling = AlignRight
+	if got := col.Pad("日本"); got != "  日本" {
+		t.Errorf("Expected %q,got %q", "  日本", got)
+	}
+}
+
+func TestAlign(t *testing.T) {
+	col := &Column{Width: 8}
+	test := []struct {
+		align Align
+		val   string
+		want  string
+	}{
+		{AlignDefault, "abc", "abc     "},
+		{AlignLeft, "abc", "abc     "},
+		{AlignRight, "abc", "     abc"},
+		{AlignCenter, "abc", "  abc   "},
+		{AlignCenter, "日本", "  日本  "},
+		{AlignJustify, "a b c", "a   b  c"},
+		{AlignJustify, "abc", "abc     "},
+		{AlignDecimal, "1.5", "   1.5  "},
+		{AlignDecimal, "12.125", "  12.125"},
+		{AlignDecimal, "7", "   7    "},
+		{AlignDecimal, "1.23456", " 1.23456"},
+		{AlignDecimal, "v1.2", "v1.2    "},
+		{AlignDecimal, "-1.5", "  -1.5  "},
+	}
+	col.DecimalLen = 3
+	for _, tt := range test {
+		col.Aling = tt.align
+		if got := col.Pad(tt.val); got != tt.want {
+			t.Errorf("Align %d: expected %q,got %q", tt.align, tt.want, got)
+		}
+	}
+}
+
+func TestPadCaption(t *testing.T) {
+	col := &Column{Width: 7, Aling: AlignRight}
+	if got := col.PadCaption("abc"); got != "    abc" {
+		t.Errorf("Expected %q,got %q", "    abc", got)
+	}
+	col.CaptionAlign = AlignCenter
+	if got := col.PadCaption("abc"); got != "  abc  " {
+		t.Errorf("Expected %q,got %q", "  abc  ", got)
+	}
+	col.CaptionAlign = AlignDefault
+	col.Aling = AlignDecimal
+	if got := col.PadCaption("abc"); got != "    abc" {
+		t.Errorf("Expected %q,got %q", "    abc", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	var columns Columns
+	if _, err := columns.NewColumn("", "Columns 1", 10, AlignLeft); err != ErrorEmptyName {
+		t.Errorf("Excepted %v, got %v", ErrorEmptyName, err)
+	}
+	if _, err := columns.NewColumn("Col1", "Columns 1", -1, AlignLeft); err != ErrorInvalidWidth {
+		t.Errorf("Excepted %v, got %v", ErrorInvalidWidth, err)
+	}
+	if err := columns.Add(&Column{Name: "Col1", Width: -5}); err != ErrorInvalidWidth {
+		t.Errorf("Excepted %v, got %v", ErrorInvalidWidth, err)
+	}
+	if columns.Len() != 0 {
+		t.Errorf("Excepted len %d, got %d", 0, columns.Len())
+	}
+}
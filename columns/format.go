@@ -0,0 +1,54 @@
+package columns
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+//TimeFormat the layout of time.Time values
+var TimeFormat = "2006-01-02 15:04:05"
+
+//A Formatter returns the text of the value of a cell
+type Formatter func(value interface{}) string
+
+//FormatValue returns the text of the value using the built-in renderers.
+//Nil values, nil pointers and zero time are rendered as empty text,
+//byte slices are rendered as text if it is valid UTF-8 otherwise as hex
+func FormatValue(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case time.Time:
+		if x.IsZero() {
+			return ""
+		}
+		return x.Format(TimeFormat)
+	case time.Duration:
+		return x.String()
+	case bool:
+		return strconv.FormatBool(x)
+	case []byte:
+		if utf8.Valid(x) {
+			return string(x)
+		}
+		return hex.EncodeToString(x)
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return ""
+		}
+	}
+	switch x := v.(type) {
+	case error:
+		return x.Error()
+	case fmt.Stringer:
+		return x.String()
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,61 @@
+package columns
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatValue(t *testing.T) {
+	var (
+		nilptr  *net.IP
+		nilerr  error
+		stamp   = time.Date(2015, 10, 13, 16, 29, 10, 0, time.UTC)
+		ip      = net.IPv4(127, 0, 0, 1)
+		notutf8 = []byte{0xff, 0x01}
+	)
+	test := []struct {
+		val  interface{}
+		want string
+	}{
+		{nil, ""},
+		{nilerr, ""},
+		{nilptr, ""},
+		{"text", "text"},
+		{stamp, "2015-10-13 16:29:10"},
+		{time.Time{}, ""},
+		{90 * time.Second, "1m30s"},
+		{true, "true"},
+		{[]byte("bytes"), "bytes"},
+		{notutf8, "ff01"},
+		{errors.New("failed"), "failed"},
+		{ip, "127.0.0.1"},
+		{42, "42"},
+	}
+	for _, tt := range test {
+		if got := FormatValue(tt.val); got != tt.want {
+			t.Errorf("FormatValue(%#v): expected %q,got %q", tt.val, tt.want, got)
+		}
+	}
+}
+
+func TestFormatter(t *testing.T) {
+	col := &Column{Number: &NumberFormat{Precision: 2}}
+	if got := col.Format(1); got != "1.00" {
+		t.Errorf("Expected %q,got %q", "1.00", got)
+	}
+	col.Formatter = func(v interface{}) string {
+		if v == nil {
+			return "-"
+		}
+		return strings.ToUpper(FormatValue(v))
+	}
+	if got := col.Format(nil); got != "-" {
+		t.Errorf("Expected %q,got %q", "-", got)
+	}
+	if got := col.Format("abc"); got != "ABC" {
+		t.Errorf("Expected %q,got %q", "ABC", got)
+	}
+}
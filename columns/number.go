@@ -0,0 +1,178 @@
+package columns
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+//PrecisionAuto keeps the shortest representation of a number
+const PrecisionAuto = -1
+
+//A Units human-readable units of numbers
+type Units int
+
+//Units of numbers
+const (
+	//UnitsNone numbers are shown as is
+	UnitsNone Units = iota
+	//UnitsIEC binary units of bytes: KiB, MiB, GiB...
+	UnitsIEC
+	//UnitsSI decimal units: k, M, G...
+	UnitsSI
+)
+
+var (
+	suffixesIEC = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	suffixesSI  = []string{"", "k", "M", "G", "T", "P", "E"}
+)
+
+//A Locale separators of numbers
+type Locale struct {
+	//Decimal the decimal separator
+	Decimal string
+	//Thousands the separator of groups of thousands
+	Thousands string
+}
+
+//Predefined locales
+var (
+	LocaleEN = Locale{Decimal: ".", Thousands: ","}
+	LocaleDE = Locale{Decimal: ",", Thousands: "."}
+	LocaleFR = Locale{Decimal: ",", Thousands: "\u202f"}
+	LocaleRU = Locale{Decimal: ",", Thousands: "\u00a0"}
+)
+
+//A NumberFormat formatting of numbers in a column
+type NumberFormat struct {
+	//Precision the count of digits after the decimal separator or PrecisionAuto
+	Precision int
+	//Grouping separates groups of thousands
+	Grouping bool
+	//Units scales numbers to human-readable units.
+	//With PrecisionAuto scaled numbers have one digit after the decimal separator
+	Units Units
+	//Locale the separators of numbers, empty decimal separator means "."
+	Locale Locale
+}
+
+//DecimalSeparator returns the decimal separator of the format
+func (f NumberFormat) DecimalSeparator() string {
+	if f.Locale.Decimal == "" {
+		return "."
+	}
+	return f.Locale.Decimal
+}
+
+//Format formats the number v. It returns false if v is not a number
+func (f NumberFormat) Format(v interface{}) (string, bool) {
+	var (
+		s       string
+		x       float64
+		integer bool
+	)
+	switch n := v.(type) {
+	case int:
+		s, x, integer = strconv.FormatInt(int64(n), 10), float64(n), true
+	case int8:
+		s, x, integer = strconv.FormatInt(int64(n), 10), float64(n), true
+	case int16:
+		s, x, integer = strconv.FormatInt(int64(n), 10), float64(n), true
+	case int32:
+		s, x, integer = strconv.FormatInt(int64(n), 10), float64(n), true
+	case int64:
+		s, x, integer = strconv.FormatInt(n, 10), float64(n), true
+	case uint:
+		s, x, integer = strconv.FormatUint(uint64(n), 10), float64(n), true
+	case uint8:
+		s, x, integer = strconv.FormatUint(uint64(n), 10), float64(n), true
+	case uint16:
+		s, x, integer = strconv.FormatUint(uint64(n), 10), float64(n), true
+	case uint32:
+		s, x, integer = strconv.FormatUint(uint64(n), 10), float64(n), true
+	case uint64:
+		s, x, integer = strconv.FormatUint(n, 10), float64(n), true
+	case float32:
+		x = float64(n)
+	case float64:
+		x = n
+	default:
+		return "", false
+	}
+
+	suffix := ""
+	if f.Units != UnitsNone && !math.IsInf(x, 0) && !math.IsNaN(x) {
+		var scaled bool
+		x, suffix, scaled = f.scale(x)
+		if scaled {
+			prec := f.Precision
+			if prec == PrecisionAuto {
+				prec = 1
+			}
+			return f.localize(strconv.FormatFloat(x, 'f', prec, 64)) + suffix, true
+		}
+	}
+	switch {
+	case integer && f.Precision > 0:
+		s += "." + strings.Repeat("0", f.Precision)
+	case !integer:
+		s = strconv.FormatFloat(x, 'f', f.Precision, 64)
+	}
+	return f.localize(s) + suffix, true
+}
+
+//scale divides x by the base of units while it is greater than the base
+func (f NumberFormat) scale(x float64) (float64, string, bool) {
+	base, suffixes := 1000.0, suffixesSI
+	if f.Units == UnitsIEC {
+		base, suffixes = 1024.0, suffixesIEC
+	}
+	i := 0
+	for math.Abs(x) >= base && i < len(suffixes)-1 {
+		x /= base
+		i++
+	}
+	return x, suffixes[i], i > 0
+}
+
+//unit returns the suffix of units of the formatted number s or empty string
+func (f NumberFormat) unit(s string) string {
+	var suffixes []string
+	switch f.Units {
+	case UnitsIEC:
+		suffixes = suffixesIEC
+	case UnitsSI:
+		suffixes = suffixesSI
+	}
+	unit := ""
+	for _, suffix := range suffixes {
+		if len(suffix) > len(unit) && strings.HasSuffix(s, suffix) {
+			unit = suffix
+		}
+	}
+	return unit
+}
+
+//localize replaces the separators of the formatted number s
+func (f NumberFormat) localize(s string) string {
+	intpart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intpart, frac = s[:i], s[i+1:]
+	}
+	sign := ""
+	if strings.HasPrefix(intpart, "-") {
+		sign, intpart = "-", intpart[1:]
+	}
+	if f.Grouping && f.Locale.Thousands != "" && len(intpart) > 3 {
+		var groups []string
+		for len(intpart) > 3 {
+			groups = append([]string{intpart[len(intpart)-3:]}, groups...)
+			intpart = intpart[:len(intpart)-3]
+		}
+		intpart = strings.Join(append([]string{intpart}, groups...), f.Locale.Thousands)
+	}
+	if frac == "" {
+		return sign + intpart
+	}
+	return sign + intpart + f.DecimalSeparator() + frac
+}
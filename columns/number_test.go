@@ -0,0 +1,70 @@
+package columns
+
+import "testing"
+
+func TestNumberFormat(t *testing.T) {
+	test := []struct {
+		format NumberFormat
+		val    interface{}
+		want   string
+	}{
+		{NumberFormat{Precision: PrecisionAuto}, 1234, "1234"},
+		{NumberFormat{Precision: PrecisionAuto}, 1.25, "1.25"},
+		{NumberFormat{Precision: 2}, 1234, "1234.00"},
+		{NumberFormat{Precision: 2}, 3.14159, "3.14"},
+		{NumberFormat{Precision: 0}, float32(2.5), "2"},
+		{NumberFormat{Precision: 2, Grouping: true, Locale: LocaleEN}, 1234567.891, "1,234,567.89"},
+		{NumberFormat{Precision: 2, Grouping: true, Locale: LocaleEN}, -1234567, "-1,234,567.00"},
+		{NumberFormat{Precision: 2, Grouping: true, Locale: LocaleDE}, 1234567.891, "1.234.567,89"},
+		{NumberFormat{Precision: 1, Grouping: true, Locale: LocaleRU}, 12345.25, "12 345,2"},
+		{NumberFormat{Precision: PrecisionAuto, Units: UnitsIEC}, 512, "512B"},
+		{NumberFormat{Precision: PrecisionAuto, Units: UnitsIEC}, int64(1536), "1.5KiB"},
+		{NumberFormat{Precision: 2, Units: UnitsIEC}, uint64(3 << 30), "3.00GiB"},
+		{NumberFormat{Precision: PrecisionAuto, Units: UnitsSI}, 1500000, "1.5M"},
+		{NumberFormat{Precision: PrecisionAuto, Units: UnitsSI, Locale: LocaleDE}, 2500, "2,5k"},
+	}
+	for _, tt := range test {
+		got, ok := tt.format.Format(tt.val)
+		if !ok || got != tt.want {
+			t.Errorf("Format(%v): expected %q,got %q", tt.val, tt.want, got)
+		}
+	}
+	if _, ok := (NumberFormat{}).Format("text"); ok {
+		t.Error("Expected not a number")
+	}
+}
+
+func TestColumnNumber(t *testing.T) {
+	col := &Column{Width: 10, Aling: AlignDecimal, Number: &NumberFormat{Precision: PrecisionAuto, Locale: LocaleDE}}
+	if got := col.Format(2.5); got != "2,5" {
+		t.Errorf("Expected %q,got %q", "2,5", got)
+	}
+	if got := col.Format("n/a"); got != "n/a" {
+		t.Errorf("Expected %q,got %q", "n/a", got)
+	}
+	if got := col.Format(nil); got != "" {
+		t.Errorf("Expected %q,got %q", "", got)
+	}
+	frac, ok := col.Fraction("12,125")
+	if frac != 3 || !ok {
+		t.Errorf("Expected %d,got %d", 3, frac)
+	}
+	col.DecimalLen = 3
+	if got := col.Pad("2,5"); got != "     2,5  " {
+		t.Errorf("Expected %q,got %q", "     2,5  ", got)
+	}
+
+	col.Number = &NumberFormat{Precision: PrecisionAuto, Units: UnitsIEC}
+	if unit := col.Unit("1.5KiB"); unit != 3 {
+		t.Errorf("Expected %d,got %d", 3, unit)
+	}
+	if frac, _ := col.Fraction("1.5KiB"); frac != 1 {
+		t.Errorf("Expected %d,got %d", 1, frac)
+	}
+	col.Width, col.DecimalLen, col.UnitLen = 11, 1, 3
+	for val, want := range map[string]string{"100B": "   100  B  ", "1.5KiB": "     1.5KiB", "n/a": "   n/a     "} {
+		if got := col.Pad(val); got != want {
+			t.Errorf("Expected %q,got %q", want, got)
+		}
+	}
+}
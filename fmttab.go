@@ -1,9 +1,12 @@
 package fmttab
 
 import (
-	"unicode/utf8"
+	"strconv"
+	"strings"
 
 	"github.com/arteev/fmttab/columns"
+	"github.com/arteev/fmttab/style"
+	"github.com/arteev/fmttab/textwidth"
 )
 
 //A Border of table
@@ -15,6 +18,8 @@ type BorderKind int
 const (
 	//WidthAuto auto sizing of width column
 	WidthAuto = columns.WidthAuto
+	//PrecisionAuto keeps the shortest representation of a number
+	PrecisionAuto = columns.PrecisionAuto
 	//BorderNone table without borders
 	BorderNone = Border(0)
 	//BorderThin table with a thin border
@@ -24,10 +29,50 @@ const (
 
 	//BorderSimple table with a simple border
 	BorderSimple = Border(3)
+	//BorderRounded table with a thin border and rounded corners
+	BorderRounded = Border(4)
+	//BorderHeavy table with a heavy border
+	BorderHeavy = Border(5)
+	//BorderHeavyOuter table with a heavy outer border and thin inner lines
+	BorderHeavyOuter = Border(6)
+	//BorderDashed table with a dashed border
+	BorderDashed = Border(7)
+	//BorderDotted table with a dotted border
+	BorderDotted = Border(8)
+	//BorderASCII table with a border of ASCII characters only
+	BorderASCII = Border(9)
+	//BorderMinimal table without border, only the header is underlined
+	BorderMinimal = Border(10)
+	//BorderDoubleFull table with double outer border and double inner lines
+	BorderDoubleFull = Border(11)
+	//AlignDefault align text along the left edge, the caption is aligned as the data
+	AlignDefault = columns.AlignDefault
 	//AlignLeft align text along the left edge
 	AlignLeft = columns.AlignLeft
 	//AlignRight align text along the right edge
 	AlignRight = columns.AlignRight
+	//AlignCenter align text on the center of column
+	AlignCenter = columns.AlignCenter
+	//AlignJustify stretch text to the width of column
+	AlignJustify = columns.AlignJustify
+	//AlignDecimal align numbers on the decimal point
+	AlignDecimal = columns.AlignDecimal
+
+	//TruncateEnd cuts the end of the text
+	TruncateEnd = columns.TruncateEnd
+	//TruncateStart cuts the beginning of the text
+	TruncateStart = columns.TruncateStart
+	//TruncateMiddle cuts the middle of the text
+	TruncateMiddle = columns.TruncateMiddle
+
+	//WrapNone the cell is always one line
+	WrapNone = columns.WrapNone
+	//WrapHard the text is cut into lines by the width of the column
+	WrapHard = columns.WrapHard
+	//WrapWord the text is split into lines between words
+	WrapWord = columns.WrapWord
+	//WrapNewline the text is split into lines only by line breaks
+	WrapNewline = columns.WrapNewline
 )
 
 //The concrete type of the object on the border of the table
@@ -50,7 +95,7 @@ const (
 //Trimend - end of line after trimming
 var Trimend = ".."
 
-//Borders predefined border types
+//Borders predefined border types. Use BorderStyle and RegisterBorder for custom borders
 var Borders = map[Border]map[BorderKind]string{
 	BorderNone: map[BorderKind]string{
 		BKVertical: " ",
@@ -90,6 +135,116 @@ var Borders = map[Border]map[BorderKind]string{
 		BKHorizontalBorder: "\u2550",
 		BKVerticalBorder:   "\u2551",
 	},
+	BorderRounded: map[BorderKind]string{
+		BKLeftTop:          "\u256d",
+		BKRighttop:         "\u256e",
+		BKRightBottom:      "\u256f",
+		BKLeftBottom:       "\u2570",
+		BKLeftToRight:      "\u251c",
+		BKRightToLeft:      "\u2524",
+		BKTopToBottom:      "\u252c",
+		BKBottomToTop:      "\u2534",
+		BKBottomCross:      "\u253c",
+		BKHorizontal:       "\u2500",
+		BKVertical:         "\u2502",
+		BKHorizontalBorder: "\u2500",
+		BKVerticalBorder:   "\u2502",
+	},
+	BorderHeavy: map[BorderKind]string{
+		BKLeftTop:          "\u250f",
+		BKRighttop:         "\u2513",
+		BKRightBottom:      "\u251b",
+		BKLeftBottom:       "\u2517",
+		BKLeftToRight:      "\u2523",
+		BKRightToLeft:      "\u252b",
+		BKTopToBottom:      "\u2533",
+		BKBottomToTop:      "\u253b",
+		BKBottomCross:      "\u254b",
+		BKHorizontal:       "\u2501",
+		BKVertical:         "\u2503",
+		BKHorizontalBorder: "\u2501",
+		BKVerticalBorder:   "\u2503",
+	},
+	BorderHeavyOuter: map[BorderKind]string{
+		BKLeftTop:          "\u250f",
+		BKRighttop:         "\u2513",
+		BKRightBottom:      "\u251b",
+		BKLeftBottom:       "\u2517",
+		BKLeftToRight:      "\u2520",
+		BKRightToLeft:      "\u2528",
+		BKTopToBottom:      "\u252f",
+		BKBottomToTop:      "\u2537",
+		BKBottomCross:      "\u253c",
+		BKHorizontal:       "\u2500",
+		BKVertical:         "\u2502",
+		BKHorizontalBorder: "\u2501",
+		BKVerticalBorder:   "\u2503",
+	},
+	BorderDashed: map[BorderKind]string{
+		BKLeftTop:          "\u250c",
+		BKRighttop:         "\u2510",
+		BKRightBottom:      "\u2518",
+		BKLeftBottom:       "\u2514",
+		BKLeftToRight:      "\u251c",
+		BKRightToLeft:      "\u2524",
+		BKTopToBottom:      "\u252c",
+		BKBottomToTop:      "\u2534",
+		BKBottomCross:      "\u253c",
+		BKHorizontal:       "\u254c",
+		BKVertical:         "\u254e",
+		BKHorizontalBorder: "\u254c",
+		BKVerticalBorder:   "\u254e",
+	},
+	BorderDotted: map[BorderKind]string{
+		BKLeftTop:          "\u250c",
+		BKRighttop:         "\u2510",
+		BKRightBottom:      "\u2518",
+		BKLeftBottom:       "\u2514",
+		BKLeftToRight:      "\u251c",
+		BKRightToLeft:      "\u2524",
+		BKTopToBottom:      "\u252c",
+		BKBottomToTop:      "\u2534",
+		BKBottomCross:      "\u253c",
+		BKHorizontal:       "\u2508",
+		BKVertical:         "\u250a",
+		BKHorizontalBorder: "\u2508",
+		BKVerticalBorder:   "\u250a",
+	},
+	BorderASCII: map[BorderKind]string{
+		BKLeftTop:          "+",
+		BKRighttop:         "+",
+		BKRightBottom:      "+",
+		BKLeftBottom:       "+",
+		BKLeftToRight:      "+",
+		BKRightToLeft:      "+",
+		BKTopToBottom:      "+",
+		BKBottomToTop:      "+",
+		BKBottomCross:      "+",
+		BKHorizontal:       "-",
+		BKVertical:         "|",
+		BKHorizontalBorder: "-",
+		BKVerticalBorder:   "|",
+	},
+	BorderMinimal: map[BorderKind]string{
+		BKBottomCross: " ",
+		BKHorizontal:  "\u2500",
+		BKVertical:    " ",
+	},
+	BorderDoubleFull: map[BorderKind]string{
+		BKLeftTop:          "\u2554",
+		BKRighttop:         "\u2557",
+		BKRightBottom:      "\u255d",
+		BKLeftBottom:       "\u255a",
+		BKLeftToRight:      "\u2560",
+		BKRightToLeft:      "\u2563",
+		BKTopToBottom:      "\u2566",
+		BKBottomToTop:      "\u2569",
+		BKBottomCross:      "\u256c",
+		BKHorizontal:       "\u2550",
+		BKVertical:         "\u2551",
+		BKHorizontalBorder: "\u2550",
+		BKVerticalBorder:   "\u2551",
+	},
 }
 
 //A DataGetter functional type for table data
@@ -98,39 +253,190 @@ type DataGetter func() (bool, map[string]interface{})
 //A Table is the repository for the columns, the data that are used for printing the table
 type Table struct {
 	dataget         DataGetter
+	rowget          RowGetter
 	border          Border
+	custom          *BorderStyle
+	bs              BorderStyle
 	caption         string
 	autoSize        int
 	CloseEachColumn bool
 	Columns         columns.Columns
-	Data            []map[string]interface{}
-	VisibleHeader   bool
-	masks           map[string]string
-	columnsvisible  columns.Columns
+	//Data the records by names of columns. The rows added by AppendRow are kept apart
+	//and written before the record of Data that was next at the time of the call
+	Data          []map[string]interface{}
+	rows          []storedRow
+	VisibleHeader bool
+	//HeaderStyle the style of the captions of columns
+	HeaderStyle style.Style
+	//RowStyle returns the style of the record with index n, can be nil.
+	//The map of the positional row is reused for the next record, so RowStyle must not keep it
+	RowStyle func(n int, rec map[string]interface{}) style.Style
+	//ColorMode defines when the styles are written by the box renderer
+	ColorMode style.Mode
+	//Zebra the style of odd records, the records are counted from zero
+	Zebra style.Style
+	//ZebraMarker the marker drawn by the box renderer before the lines of odd records,
+	//the other lines of the table are indented by its width
+	ZebraMarker string
+	//FooterStyle the style of the rows of the footer and the subtotals of groups
+	FooterStyle style.Style
+	//GroupStyle the style of the headers of groups
+	GroupStyle     style.Style
+	footer         []map[string]interface{}
+	groupBy        string
+	subtotals      []map[string]interface{}
+	headerGroups   []*HeaderGroup
+	sortKeys       []sortKey
+	filters        []Predicate
+	filtered       int
+	rules          []*Rule
+	columnsvisible columns.Columns
+	rowMap         map[string]interface{}
 }
 
-// A trimEnds supplements the text with special characters by limiting the length of the text column width
+// A trimEnds supplements the text with special characters by limiting the display width of the text column width
 func trimEnds(val string, max int) string {
-	if utf8.RuneCountInString(val) <= max {
-		return val
+	return textwidth.Truncate(val, max, Trimend)
+}
+
+//ellipsis returns the mark of the truncated text of the column
+func ellipsis(c *columns.Column) string {
+	if c.Ellipsis == "" {
+		return Trimend
+	}
+	return c.Ellipsis
+}
+
+//trimColumn cuts the text to the width of the column. The text is never cut inside a grapheme cluster
+func trimColumn(c *columns.Column, val string) string {
+	return trimWidth(c, val, c.GetWidth())
+}
+
+//trimWidth cuts the text as trimColumn to the width of the cell spanning several columns
+func trimWidth(c *columns.Column, val string, width int) string {
+	mark := ellipsis(c)
+	switch c.Truncate {
+	case columns.TruncateStart:
+		return textwidth.TruncateStart(val, width, mark)
+	case columns.TruncateMiddle:
+		return textwidth.TruncateMiddle(val, width, mark)
+	}
+	return textwidth.Truncate(val, width, mark)
+}
+
+//cellLines splits the text of the cell into lines according to the wrap mode of the column
+func cellLines(c *columns.Column, val string) []string {
+	return wrapCell(c, val, c.GetWidth())
+}
+
+//wrapCell splits the text as cellLines for the cell of width spanning several columns
+func wrapCell(c *columns.Column, val string, width int) []string {
+	var lines []string
+	switch c.Wrap {
+	case columns.WrapHard:
+		lines = textwidth.WrapHard(val, width)
+	case columns.WrapWord:
+		lines = textwidth.WrapWords(val, width)
+	case columns.WrapNewline:
+		lines = textwidth.Lines(val)
+	default:
+		return appendCell(nil, c, val, width)
+	}
+	if c.MaxLines > 0 && len(lines) > c.MaxLines {
+		lines = lines[:c.MaxLines]
+		last, mark := lines[c.MaxLines-1], ellipsis(c)
+		lines[c.MaxLines-1] = textwidth.Truncate(last, width-textwidth.String(mark), "") + mark
 	}
-	trimlen := utf8.RuneCountInString(Trimend)
-	if trimlen < max {
-		return val[:max-trimlen] + Trimend
+	for i := range lines {
+		lines[i] = trimWidth(c, lines[i], width)
+	}
+	return lines
+}
+
+//appendCell appends the lines of the cell split as wrapCell to lines
+func appendCell(lines []string, c *columns.Column, val string, width int) []string {
+	if c.Wrap != columns.WrapNone {
+		return append(lines, wrapCell(c, val, width)...)
 	}
-	return Trimend[:max]
+	if strings.IndexByte(val, '\n') >= 0 {
+		val = strings.Join(textwidth.Lines(val), " ")
+	}
+	return append(lines, trimWidth(c, val, width))
+}
+
+//A ColumnError describes the failure of the operation with the column
+type ColumnError struct {
+	Name string
+	Err  error
+}
+
+func (e *ColumnError) Error() string {
+	return e.Err.Error() + ": " + strconv.Quote(e.Name)
 }
 
-//AddColumn adds a column to the table
+//Unwrap returns the cause of the error: columns.ErrorAlreadyExists, columns.ErrorInvalidWidth,
+//columns.ErrorEmptyName, columns.ErrorNilColumn or columns.ErrorNotFound
+func (e *ColumnError) Unwrap() error {
+	return e.Err
+}
+
+//AddColumn adds a column to the table. It panics with the error of columns package
+//if the column can not be added, see TryAddColumn
 func (t *Table) AddColumn(name string, width int, aling columns.Align) *Table {
-	_, err := t.Columns.NewColumn(name, name, width, aling)
-	if err != nil {
-		//fix it
+	if _, err := t.Columns.NewColumn(name, name, width, aling); err != nil {
 		panic(err)
 	}
 	return t
 }
 
+//TryAddColumn adds a column to the table. It returns *ColumnError if the column already exists,
+//the name is empty or the width is negative
+func (t *Table) TryAddColumn(name string, width int, aling columns.Align) error {
+	if _, err := t.Columns.NewColumn(name, name, width, aling); err != nil {
+		return &ColumnError{Name: name, Err: err}
+	}
+	return nil
+}
+
+//AddColumns adds the columns to the table. If one of the columns can not be added,
+//none of them is added and *ColumnError is returned. The nil column is an error
+func (t *Table) AddColumns(cols ...*columns.Column) error {
+	names := make(map[string]bool, len(cols))
+	for _, col := range cols {
+		if col == nil {
+			return &ColumnError{Err: columns.ErrorNilColumn}
+		}
+		if err := col.Validate(); err != nil {
+			return &ColumnError{Name: col.Name, Err: err}
+		}
+		if names[col.Name] || t.Columns.FindByName(col.Name) != nil {
+			return &ColumnError{Name: col.Name, Err: columns.ErrorAlreadyExists}
+		}
+		names[col.Name] = true
+	}
+	for _, col := range cols {
+		if err := t.Columns.Add(col); err != nil {
+			return &ColumnError{Name: col.Name, Err: err}
+		}
+	}
+	return nil
+}
+
+//Column returns the column by name or *ColumnError if the column is not found
+func (t *Table) Column(name string) (*columns.Column, error) {
+	if c := t.Columns.FindByName(name); c != nil {
+		return c, nil
+	}
+	return nil, &ColumnError{Name: name, Err: columns.ErrorNotFound}
+}
+
+//AddNumberColumn adds a numeric column aligned on the decimal separator
+func (t *Table) AddNumberColumn(name string, width int, format columns.NumberFormat) *Table {
+	t.AddColumn(name, width, columns.AlignDecimal)
+	t.Columns.FindByName(name).Number = &format
+	return t
+}
+
 //AppendData adds the data to the table
 func (t *Table) AppendData(rec map[string]interface{}) *Table {
 	t.Data = append(t.Data, rec)
@@ -140,6 +446,7 @@ func (t *Table) AppendData(rec map[string]interface{}) *Table {
 //ClearData removes data from a table
 func (t *Table) ClearData() *Table {
 	t.Data = nil
+	t.rows = nil
 	return t
 }
 
@@ -154,13 +461,13 @@ func (t *Table) AutoSize(enabled bool, destWidth int) {
 
 //CountData the amount of data in the table
 func (t *Table) CountData() int {
-	return len(t.Data)
+	return len(t.Data) + len(t.rows)
 }
 
 //SetBorder - set  type of border table
 func (t *Table) SetBorder(b Border) {
-	//TODO: set custom border
 	t.border = b
+	t.custom = nil
 }
 
 //GetBorder - get current border
@@ -177,3 +484,13 @@ func New(caption string, border Border, datagetter DataGetter) *Table {
 		VisibleHeader: true,
 	}
 }
+
+//NewRows creates a Table object with the data as rows of values. RowGetter can be nil
+func NewRows(caption string, border Border, rowgetter RowGetter) *Table {
+	return &Table{
+		caption:       caption,
+		border:        border,
+		rowget:        rowgetter,
+		VisibleHeader: true,
+	}
+}
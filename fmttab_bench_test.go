@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"math/rand"
+
+	"github.com/arteev/fmttab/style"
 )
 
 func makeTable() *Table {
@@ -43,3 +45,73 @@ func BenchmarkString(b *testing.B) {
 		}
 	}
 }
+
+func makeRowsTable() *Table {
+	tab := New("Table", BorderThin, nil)
+	tab.AddColumn("Column1", 8, AlignLeft)
+	tab.AddColumn("Column2", 16, AlignLeft)
+	tab.CloseEachColumn = true
+	for n := 0; n < 100; n++ {
+		tab.AppendRow(n*rand.Int(), "data")
+	}
+	return tab
+}
+
+func BenchmarkAppendData(b *testing.B) {
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		makeTable()
+	}
+}
+
+func BenchmarkAppendRow(b *testing.B) {
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		makeRowsTable()
+	}
+}
+
+func BenchmarkWriteToData(b *testing.B) {
+	tab := makeTable()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_, err := tab.WriteTo(ioutil.Discard)
+		if err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func BenchmarkWriteToRows(b *testing.B) {
+	tab := makeRowsTable()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_, err := tab.WriteTo(ioutil.Discard)
+		if err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+//The positional rows are matched by rules without building a map per record
+func benchmarkWriteToRule(b *testing.B, tab *Table) {
+	tab.AddRule(Eq("Column2", "none"), style.Style{Bold: true})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_, err := tab.WriteTo(ioutil.Discard)
+		if err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func BenchmarkWriteToDataRule(b *testing.B) {
+	benchmarkWriteToRule(b, makeTable())
+}
+
+func BenchmarkWriteToRowsRule(b *testing.B) {
+	benchmarkWriteToRule(b, makeRowsTable())
+}
@@ -0,0 +1,225 @@
+package fmttab
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/arteev/fmttab/textwidth"
+)
+
+//ErrorBorderPiece the piece of the border is wider than one character
+var ErrorBorderPiece = errors.New("Border piece must be one character wide")
+
+//ErrorUnknownBorder the border is not registered
+var ErrorUnknownBorder = errors.New("Unknown border")
+
+//ErrorBorderName the name of the border is empty or the name of a built-in border
+var ErrorBorderName = errors.New("Invalid name of border")
+
+//ErrorEmptyBorder the border has no pieces
+var ErrorEmptyBorder = errors.New("Empty border")
+
+//A BorderStyle contains all pieces of the border of the table.
+//Every piece is either empty or one character wide.
+//The missing junctions and corners are filled by the lines they join
+type BorderStyle struct {
+	LeftTop          string
+	RightTop         string
+	RightBottom      string
+	LeftBottom       string
+	LeftToRight      string
+	RightToLeft      string
+	TopToBottom      string
+	BottomToTop      string
+	BottomCross      string
+	Horizontal       string
+	Vertical         string
+	HorizontalBorder string
+	VerticalBorder   string
+}
+
+//A BorderError describes the invalid piece of the border
+type BorderError struct {
+	Kind  BorderKind
+	Piece string
+}
+
+func (e *BorderError) Error() string {
+	return ErrorBorderPiece.Error() + ": " + e.Piece
+}
+
+//Unwrap returns ErrorBorderPiece
+func (e *BorderError) Unwrap() error {
+	return ErrorBorderPiece
+}
+
+//allBorderKinds lists the kinds of pieces of the border
+var allBorderKinds = []BorderKind{
+	BKLeftTop, BKRighttop, BKRightBottom, BKLeftBottom,
+	BKLeftToRight, BKRightToLeft, BKTopToBottom, BKBottomToTop, BKBottomCross,
+	BKHorizontal, BKVertical, BKHorizontalBorder, BKVerticalBorder,
+}
+
+//NewBorderStyle creates a BorderStyle by pieces as in Borders
+func NewBorderStyle(pieces map[BorderKind]string) BorderStyle {
+	var s BorderStyle
+	for kind, piece := range pieces {
+		if p := s.piece(kind); p != nil {
+			*p = piece
+		}
+	}
+	return s
+}
+
+//Get returns the piece of the border by kind
+func (s BorderStyle) Get(kind BorderKind) string {
+	if p := s.piece(kind); p != nil {
+		return *p
+	}
+	return ""
+}
+
+func (s *BorderStyle) piece(kind BorderKind) *string {
+	switch kind {
+	case BKLeftTop:
+		return &s.LeftTop
+	case BKRighttop:
+		return &s.RightTop
+	case BKRightBottom:
+		return &s.RightBottom
+	case BKLeftBottom:
+		return &s.LeftBottom
+	case BKLeftToRight:
+		return &s.LeftToRight
+	case BKRightToLeft:
+		return &s.RightToLeft
+	case BKTopToBottom:
+		return &s.TopToBottom
+	case BKBottomToTop:
+		return &s.BottomToTop
+	case BKBottomCross:
+		return &s.BottomCross
+	case BKHorizontal:
+		return &s.Horizontal
+	case BKVertical:
+		return &s.Vertical
+	case BKHorizontalBorder:
+		return &s.HorizontalBorder
+	case BKVerticalBorder:
+		return &s.VerticalBorder
+	}
+	return nil
+}
+
+//Validate checks that every piece of the border is empty or one character wide
+func (s BorderStyle) Validate() error {
+	for _, kind := range allBorderKinds {
+		piece := s.Get(kind)
+		if piece != "" && (textwidth.String(piece) != 1 || len(textwidth.Graphemes(piece)) != 1) {
+			return &BorderError{Kind: kind, Piece: piece}
+		}
+	}
+	return nil
+}
+
+//resolve fills the missing junctions and corners by the lines they join,
+//so that every line of the table has the same width
+func (s BorderStyle) resolve() BorderStyle {
+	fill := func(piece *string, line, vertical string) {
+		if *piece == "" {
+			*piece = strings.Repeat(line, textwidth.String(vertical))
+		}
+	}
+	fill(&s.LeftTop, s.HorizontalBorder, s.VerticalBorder)
+	fill(&s.TopToBottom, s.HorizontalBorder, s.Vertical)
+	fill(&s.RightTop, s.HorizontalBorder, s.VerticalBorder)
+	fill(&s.LeftToRight, s.Horizontal, s.VerticalBorder)
+	fill(&s.BottomCross, s.Horizontal, s.Vertical)
+	fill(&s.RightToLeft, s.Horizontal, s.VerticalBorder)
+	fill(&s.LeftBottom, s.HorizontalBorder, s.VerticalBorder)
+	fill(&s.BottomToTop, s.HorizontalBorder, s.Vertical)
+	fill(&s.RightBottom, s.HorizontalBorder, s.VerticalBorder)
+	return s
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]BorderStyle{}
+	//builtins the names of the built-in borders, they can not be replaced
+	builtins = map[string]bool{}
+)
+
+func init() {
+	for name, border := range map[string]Border{
+		"none":        BorderNone,
+		"thin":        BorderThin,
+		"double":      BorderDouble,
+		"simple":      BorderSimple,
+		"rounded":     BorderRounded,
+		"heavy":       BorderHeavy,
+		"heavy-outer": BorderHeavyOuter,
+		"dashed":      BorderDashed,
+		"dotted":      BorderDotted,
+		"ascii":       BorderASCII,
+		"minimal":     BorderMinimal,
+		"double-full": BorderDoubleFull,
+	} {
+		registry[name] = NewBorderStyle(Borders[border])
+		builtins[name] = true
+	}
+}
+
+//RegisterBorder registers the border style by name. It is safe for concurrent use.
+//It returns ErrorBorderName if the name is empty or the name of a built-in border
+//and ErrorEmptyBorder if the border has no pieces
+func RegisterBorder(name string, style BorderStyle) error {
+	if name == "" || builtins[name] {
+		return ErrorBorderName
+	}
+	if style == (BorderStyle{}) {
+		return ErrorEmptyBorder
+	}
+	if err := style.Validate(); err != nil {
+		return err
+	}
+	registryMu.Lock()
+	registry[name] = style
+	registryMu.Unlock()
+	return nil
+}
+
+//LookupBorder returns the registered border style by name
+func LookupBorder(name string) (BorderStyle, bool) {
+	registryMu.RLock()
+	style, ok := registry[name]
+	registryMu.RUnlock()
+	return style, ok
+}
+
+//SetBorderStyle sets the custom border of the table
+func (t *Table) SetBorderStyle(style BorderStyle) error {
+	if err := style.Validate(); err != nil {
+		return err
+	}
+	t.custom = &style
+	return nil
+}
+
+//SetBorderName sets the registered border of the table by name
+func (t *Table) SetBorderName(name string) error {
+	style, ok := LookupBorder(name)
+	if !ok {
+		return ErrorUnknownBorder
+	}
+	t.custom = &style
+	return nil
+}
+
+//BorderStyle returns the current border style of the table
+func (t *Table) BorderStyle() BorderStyle {
+	if t.custom != nil {
+		return *t.custom
+	}
+	return NewBorderStyle(Borders[t.border])
+}
@@ -1,8 +1,16 @@
 package fmttab
 
 import (
-	"testing"
+	"errors"
+	"flag"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
 	"github.com/arteev/fmttab/eol"
 )
 
@@ -105,3 +113,141 @@ func TestReplaceBorder(t *testing.T) {
 		t.Errorf("Excepted %v, got:%v", BorderDouble, tab.border)
 	}
 }
+
+func TestBorderStyle(t *testing.T) {
+	tab := New("", BorderNone, nil)
+	err := tab.SetBorderStyle(BorderStyle{
+		Vertical:         "|",
+		Horizontal:       "-",
+		HorizontalBorder: "_",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tab.AddColumn("Key", 5, AlignLeft).
+		AddColumn("Value", 5, AlignRight)
+	tab.AppendData(map[string]interface{}{"Key": "k1", "Value": "v1"})
+	org := fmt.Sprintf("___________%[1]sKey  |Value%[1]s-----------%[1]sk1   |   v1%[1]s___________%[1]s", eol.EOL)
+	res := tab.String()
+	if org != res {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+
+	tab.SetBorder(BorderThin)
+	if tab.BorderStyle() != NewBorderStyle(Borders[BorderThin]) {
+		t.Errorf("Excepted thin border, got %v", tab.BorderStyle())
+	}
+}
+
+func TestBorderStyleValidate(t *testing.T) {
+	tab := New("", BorderThin, nil)
+	for _, piece := range []string{"||", "日", "ab"} {
+		err := tab.SetBorderStyle(BorderStyle{Vertical: piece})
+		if berr, ok := err.(*BorderError); !ok || berr.Kind != BKVertical {
+			t.Errorf("Excepted *BorderError, got %v", err)
+		}
+		if !errors.Is(err, ErrorBorderPiece) {
+			t.Errorf("Excepted %v, got %v", ErrorBorderPiece, err)
+		}
+		if err := RegisterBorder("invalid", BorderStyle{HorizontalBorder: piece}); err == nil {
+			t.Error("Excepted error")
+		}
+	}
+	if tab.BorderStyle() != NewBorderStyle(Borders[BorderThin]) {
+		t.Errorf("Excepted thin border, got %v", tab.BorderStyle())
+	}
+	if err := tab.SetBorderName("invalid"); err != ErrorUnknownBorder {
+		t.Errorf("Excepted %v, got %v", ErrorUnknownBorder, err)
+	}
+}
+
+func TestRegisterBorder(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := "test" + strconv.Itoa(i)
+			if err := RegisterBorder(name, BorderStyle{Vertical: "!"}); err != nil {
+				t.Error(err)
+			}
+			if _, ok := LookupBorder(name); !ok {
+				t.Errorf("Excepted border %q", name)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, name := range []string{"none", "thin", "double", "simple"} {
+		if _, ok := LookupBorder(name); !ok {
+			t.Errorf("Excepted border %q", name)
+		}
+		if err := RegisterBorder(name, BorderStyle{Vertical: "!"}); err != ErrorBorderName {
+			t.Errorf("Excepted %v, got %v", ErrorBorderName, err)
+		}
+	}
+	if err := RegisterBorder("", BorderStyle{Vertical: "!"}); err != ErrorBorderName {
+		t.Errorf("Excepted %v, got %v", ErrorBorderName, err)
+	}
+	if err := RegisterBorder("empty", BorderStyle{}); err != ErrorEmptyBorder {
+		t.Errorf("Excepted %v, got %v", ErrorEmptyBorder, err)
+	}
+	tab := New("Table", BorderNone, nil)
+	tab.AddColumn("Column1", 8, AlignLeft)
+	if err := tab.SetBorderName("double"); err != nil {
+		t.Fatal(err)
+	}
+	tab2 := New("Table", BorderDouble, nil)
+	tab2.AddColumn("Column1", 8, AlignLeft)
+	if tab.String() != tab2.String() {
+		t.Errorf("Excepted \n%q, got:\n%q", tab2.String(), tab.String())
+	}
+}
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestBorderGolden(t *testing.T) {
+	for _, name := range []string{"none", "thin", "double", "simple", "rounded", "heavy", "heavy-outer",
+		"dashed", "dotted", "ascii", "minimal", "double-full"} {
+		tab := New("Table", BorderNone, nil)
+		if err := tab.SetBorderName(name); err != nil {
+			t.Fatal(err)
+		}
+		tab.CloseEachColumn = true
+		tab.AddColumn("Name", WidthAuto, AlignLeft).
+			AddColumn("Value", 7, AlignRight)
+		tab.AppendRow("first", 1)
+		tab.AppendRow("second", 22)
+		res := strings.Replace(tab.String(), eol.EOL, "\n", -1)
+
+		golden := filepath.Join("testdata", "borders", name+".golden")
+		if *update {
+			if err := ioutil.WriteFile(golden, []byte(res), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		org, err := ioutil.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(org) != res {
+			t.Errorf("Border %s: excepted \n%s, got:\n%s", name, org, res)
+		}
+	}
+}
+
+func TestBordersComplete(t *testing.T) {
+	for border, pieces := range Borders {
+		if err := NewBorderStyle(pieces).Validate(); err != nil {
+			t.Errorf("Border %d: %v", border, err)
+		}
+		if border == BorderNone || border == BorderSimple || border == BorderMinimal {
+			continue
+		}
+		for _, kind := range allBorderKinds {
+			if pieces[kind] == "" {
+				t.Errorf("Border %d: excepted piece %d", border, kind)
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package fmttab
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/arteev/fmttab/columns"
+)
+
+//WriteCSV writes the visible columns of the table as comma-separated values to w.
+//The captions of columns are written as the first record if VisibleHeader is set.
+//The spans are flattened as in WriteJSON and the groups of the header are not written
+func (t *Table) WriteCSV(w io.Writer) (int64, error) {
+	return t.writeDelimited(w, ',')
+}
+
+//WriteTSV writes the visible columns of the table as tab-separated values to w.
+//The captions of columns are written as the first record if VisibleHeader is set
+func (t *Table) WriteTSV(w io.Writer) (int64, error) {
+	return t.writeDelimited(w, '\t')
+}
+
+func (t *Table) writeDelimited(w io.Writer, comma rune) (int64, error) {
+	t.columnsvisible = t.Columns.ColumnsVisible()
+	if t.columnsvisible.Len() == 0 {
+		return 0, nil
+	}
+	cw := &countWriter{w: w}
+	writer := csv.NewWriter(cw)
+	writer.Comma = comma
+
+	fields := make([]string, t.columnsvisible.Len())
+	if t.VisibleHeader {
+		num := 0
+		t.columnsvisible.Visit(func(c *columns.Column) error {
+			fields[num] = c.Caption
+			num++
+			return nil
+		})
+		if err := writer.Write(fields); err != nil {
+			return cw.n, err
+		}
+	}
+	err := t.visitRecords(func(r record) error {
+		num := 0
+		t.columnsvisible.Visit(func(c *columns.Column) error {
+			fields[num] = c.Format(r.value(num, c))
+			num++
+			return nil
+		})
+		return writer.Write(fields)
+	})
+	if err != nil {
+		return cw.n, err
+	}
+	writer.Flush()
+	return cw.n, writer.Error()
+}
@@ -0,0 +1,65 @@
+package fmttab
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/arteev/fmttab/columns"
+)
+
+func TestWriteCSV(t *testing.T) {
+	tab := New("Table", BorderThin, nil)
+	tab.AddColumn("Name", 10, AlignLeft).
+		AddColumn("Hidden", 10, AlignLeft).
+		AddNumberColumn("Price", 10, columns.NumberFormat{Precision: 2})
+	tab.Columns.FindByName("Hidden").Visible = false
+	tab.Columns.FindByName("Price").Caption = "Price, $"
+	tab.AppendData(map[string]interface{}{
+		"Name":   "say \"hi\"",
+		"Hidden": "x",
+		"Price":  1.5,
+	})
+	tab.AppendRow("tab\there", 2)
+
+	var buf bytes.Buffer
+	n, err := tab.WriteCSV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	org := "Name,\"Price, $\"\n\"say \"\"hi\"\"\",1.50\ntab\there,2.00\n"
+	if org != buf.String() {
+		t.Errorf("Excepted \n%q, got:\n%q", org, buf.String())
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("Excepted count write:%d, got: %d", buf.Len(), n)
+	}
+
+	buf.Reset()
+	tab.VisibleHeader = false
+	if _, err := tab.WriteTSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	org = "\"say \"\"hi\"\"\"\t1.50\n\"tab\there\"\t2.00\n"
+	if org != buf.String() {
+		t.Errorf("Excepted \n%q, got:\n%q", org, buf.String())
+	}
+}
+
+func TestWriteCSVGetter(t *testing.T) {
+	cur := 0
+	tab := New("", BorderThin, func() (bool, map[string]interface{}) {
+		if cur >= 2 {
+			return false, nil
+		}
+		cur++
+		return true, map[string]interface{}{"ID": cur}
+	})
+	tab.AddColumn("ID", 10, AlignLeft)
+	var buf bytes.Buffer
+	if _, err := tab.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if org := "ID\n1\n2\n"; org != buf.String() {
+		t.Errorf("Excepted \n%q, got:\n%q", org, buf.String())
+	}
+}
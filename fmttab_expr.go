@@ -0,0 +1,307 @@
+package fmttab
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//ErrorExprSyntax the filter expression is invalid
+var ErrorExprSyntax = errors.New("Invalid filter expression")
+
+//An ExprError describes the invalid filter expression
+type ExprError struct {
+	//Pos the byte offset of the error in the expression
+	Pos int
+	Msg string
+}
+
+func (e *ExprError) Error() string {
+	return ErrorExprSyntax.Error() + ": " + e.Msg + " at " + strconv.Itoa(e.Pos)
+}
+
+//Unwrap returns ErrorExprSyntax
+func (e *ExprError) Unwrap() error {
+	return ErrorExprSyntax
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenNumber
+	tokenString
+	tokenOp
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+//operators ordered so that longer operators are matched first
+var exprOperators = []string{"==", "!=", ">=", "<=", ">", "<", "~"}
+
+func lexExpr(expr string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		r, size := utf8.DecodeRuneInString(expr[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+			continue
+		case strings.HasPrefix(expr[i:], "&&"):
+			tokens = append(tokens, token{tokenAnd, "&&", i})
+			i += 2
+			continue
+		case strings.HasPrefix(expr[i:], "||"):
+			tokens = append(tokens, token{tokenOr, "||", i})
+			i += 2
+			continue
+		case r == '(':
+			tokens = append(tokens, token{tokenLParen, "(", i})
+			i++
+			continue
+		case r == ')':
+			tokens = append(tokens, token{tokenRParen, ")", i})
+			i++
+			continue
+		case r == '"' || r == '\'':
+			end := i + 1
+			for end < len(expr) && expr[end] != byte(r) {
+				if expr[end] == '\\' && r == '"' {
+					end++
+				}
+				end++
+			}
+			if end >= len(expr) {
+				return nil, &ExprError{Pos: i, Msg: "unterminated string"}
+			}
+			text := expr[i+1 : end]
+			if r == '"' {
+				s, err := strconv.Unquote(expr[i : end+1])
+				if err != nil {
+					return nil, &ExprError{Pos: i, Msg: "invalid string"}
+				}
+				text = s
+			}
+			tokens = append(tokens, token{tokenString, text, i})
+			i = end + 1
+			continue
+		}
+		if op := matchOperator(expr[i:]); op != "" {
+			tokens = append(tokens, token{tokenOp, op, i})
+			i += len(op)
+			continue
+		}
+		if r == '!' {
+			tokens = append(tokens, token{tokenNot, "!", i})
+			i++
+			continue
+		}
+		end := i
+		for end < len(expr) {
+			r, size := utf8.DecodeRuneInString(expr[end:])
+			if !isWordRune(r) {
+				break
+			}
+			end += size
+		}
+		if end == i {
+			return nil, &ExprError{Pos: i, Msg: "unexpected " + strconv.QuoteRune(r)}
+		}
+		kind := tokenWord
+		if isNumber(expr[i:end]) {
+			kind = tokenNumber
+		}
+		tokens = append(tokens, token{kind, expr[i:end], i})
+		i = end
+	}
+	return append(tokens, token{tokenEOF, "", len(expr)}), nil
+}
+
+func matchOperator(s string) string {
+	for _, op := range exprOperators {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+//isNumber reports whether the word is a number. The number begins with a digit after the optional sign,
+//so the words as inf or nan are not numbers
+func isNumber(word string) bool {
+	digits := strings.TrimLeft(word, "+-")
+	if len(word)-len(digits) > 1 || digits == "" || digits[0] < '0' || digits[0] > '9' {
+		return false
+	}
+	_, err := strconv.ParseFloat(word, 64)
+	return err == nil
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '-' || r == '+' || r == ':'
+}
+
+//An exprParser the recursive descent parser of filter expressions
+type exprParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *exprParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+//ParseFilter parses the expression of the predicate of records, for example:
+//
+//	Size>1000 && Dir==false
+//	(Status=="FAIL" || Status==WARN) && !Name~tmp
+//
+//The comparisons are ==, !=, >, >=, <, <= and ~ for the text containing the value ignoring case.
+//The name of a column is on the left, the value is on the right: a number, a quoted string,
+//true, false, nil or a word. The comparisons are combined by &&, || and ! with parentheses
+func ParseFilter(expr string) (Predicate, error) {
+	tokens, err := lexExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{tokens: tokens}
+	pred, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, &ExprError{Pos: tok.pos, Msg: "unexpected " + strconv.Quote(tok.text)}
+	}
+	return pred, nil
+}
+
+func (p *exprParser) parseOr() (Predicate, error) {
+	pred, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	preds := []Predicate{pred}
+	for p.peek().kind == tokenOr {
+		p.next()
+		pred, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, pred)
+	}
+	if len(preds) == 1 {
+		return preds[0], nil
+	}
+	return Or(preds...), nil
+}
+
+func (p *exprParser) parseAnd() (Predicate, error) {
+	pred, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	preds := []Predicate{pred}
+	for p.peek().kind == tokenAnd {
+		p.next()
+		pred, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, pred)
+	}
+	if len(preds) == 1 {
+		return preds[0], nil
+	}
+	return And(preds...), nil
+}
+
+func (p *exprParser) parseUnary() (Predicate, error) {
+	switch tok := p.peek(); tok.kind {
+	case tokenNot:
+		p.next()
+		pred, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not(pred), nil
+	case tokenLParen:
+		p.next()
+		pred, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.next(); tok.kind != tokenRParen {
+			return nil, &ExprError{Pos: tok.pos, Msg: "expected )"}
+		}
+		return pred, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *exprParser) parseComparison() (Predicate, error) {
+	column := p.next()
+	if column.kind != tokenWord && column.kind != tokenString {
+		return nil, &ExprError{Pos: column.pos, Msg: "expected name of column"}
+	}
+	op := p.next()
+	if op.kind != tokenOp {
+		return nil, &ExprError{Pos: op.pos, Msg: "expected comparison"}
+	}
+	lit := p.next()
+	var value interface{}
+	switch lit.kind {
+	case tokenNumber:
+		value, _ = strconv.ParseFloat(lit.text, 64)
+	case tokenString:
+		value = lit.text
+	case tokenWord:
+		switch lit.text {
+		case "true":
+			value = true
+		case "false":
+			value = false
+		case "nil":
+			value = nil
+		default:
+			value = lit.text
+		}
+	default:
+		return nil, &ExprError{Pos: lit.pos, Msg: "expected value"}
+	}
+
+	switch op.text {
+	case "==":
+		return Eq(column.text, value), nil
+	case "!=":
+		return Ne(column.text, value), nil
+	case ">":
+		return Gt(column.text, value), nil
+	case ">=":
+		return Ge(column.text, value), nil
+	case "<":
+		return Lt(column.text, value), nil
+	case "<=":
+		return Le(column.text, value), nil
+	}
+	return Contains(column.text, lit.text), nil
+}
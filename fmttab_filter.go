@@ -0,0 +1,101 @@
+package fmttab
+
+import (
+	"strings"
+
+	"github.com/arteev/fmttab/columns"
+)
+
+//Filter adds the predicate of records written to the output, the records not matching any predicate are skipped.
+//The predicates are applied to the stored records and to the records of the getter.
+//The predicates see nil for the hidden columns of positional rows, see Predicate
+func (t *Table) Filter(pred Predicate) *Table {
+	t.filters = append(t.filters, pred)
+	return t
+}
+
+//FilterExpr parses the expression by ParseFilter and adds it as the predicate of records
+func (t *Table) FilterExpr(expr string) error {
+	pred, err := ParseFilter(expr)
+	if err != nil {
+		return err
+	}
+	t.Filter(pred)
+	return nil
+}
+
+//ClearFilter removes all predicates of records
+func (t *Table) ClearFilter() {
+	t.filters = nil
+}
+
+//Filtered returns the count of the records skipped by the predicates.
+//For the stored records it is counted on each call.
+//The records of the getter are read once, so for the getter it is the count of the records skipped so far
+//by the current output or by the last one: the functions of the footer get the count of the output,
+//but the caption and the header are written before the count is known
+func (t *Table) Filtered() int {
+	if t.streaming() {
+		return t.filtered
+	}
+	cols := t.Columns.ColumnsVisible()
+	n := 0
+	t.visitStored(func(r record) error {
+		if !t.matchColumns(r, &cols) {
+			n++
+		}
+		return nil
+	})
+	return n
+}
+
+//match reports whether the record matches all predicates of the table
+func (t *Table) match(r record) bool {
+	return t.matchColumns(r, &t.columnsvisible)
+}
+
+//matchColumns reports whether the record bound to the visible columns cols matches all predicates of the table
+func (t *Table) matchColumns(r record, cols *columns.Columns) bool {
+	if len(t.filters) == 0 {
+		return true
+	}
+	data := t.recordMap(r, cols)
+	for _, pred := range t.filters {
+		if !pred(data) {
+			return false
+		}
+	}
+	return true
+}
+
+//matched returns the function calling f for the matching records and counting the skipped records
+func (t *Table) matched(f func(r record) error) func(r record) error {
+	if len(t.filters) == 0 {
+		return f
+	}
+	return func(r record) error {
+		if !t.match(r) {
+			t.filtered++
+			return nil
+		}
+		return f(r)
+	}
+}
+
+//visitFiltered bypasses the stored records matching the predicates
+func (t *Table) visitFiltered(f func(r record) error) error {
+	return t.visitStored(func(r record) error {
+		if !t.match(r) {
+			return nil
+		}
+		return f(r)
+	})
+}
+
+//Contains matches the records with the text of the value of the column containing substr ignoring case
+func Contains(column, substr string) Predicate {
+	substr = strings.ToLower(substr)
+	return func(rec map[string]interface{}) bool {
+		return strings.Contains(strings.ToLower(columns.FormatValue(rec[column])), substr)
+	}
+}
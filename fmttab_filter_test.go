@@ -0,0 +1,146 @@
+package fmttab
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/arteev/fmttab/eol"
+)
+
+func TestParseFilter(t *testing.T) {
+	rec := map[string]interface{}{
+		"Name":   "access.log",
+		"Size":   int64(2048),
+		"Dir":    false,
+		"Status": "FAIL",
+		"Owner":  nil,
+		"Limit":  "inf",
+	}
+	test := []struct {
+		expr string
+		want bool
+	}{
+		{"Size>1000 && Dir==false", true},
+		{"Size>1000 && Dir==true", false},
+		{"Size >= 2048", true},
+		{"Size<2e3", false},
+		{"Status==FAIL", true},
+		{`Status=="OK" || Status=='FAIL'`, true},
+		{"!(Status==OK)", true},
+		{"Name~LOG && !Name~tmp", true},
+		{"Owner==nil", true},
+		{"Missing!=x", true},
+		{"(Size>1 || Dir==true) && Status!=FAIL", false},
+		{`"Name"=="access.log"`, true},
+		{"Limit==inf && Limit!=nan", true},
+		{"Size>-1", true},
+	}
+	for _, tt := range test {
+		pred, err := ParseFilter(tt.expr)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.expr, err)
+			continue
+		}
+		if got := pred(rec); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.expr, tt.want, got)
+		}
+	}
+
+	for expr, pos := range map[string]int{
+		"Size>":          5,
+		"Size 1000":      5,
+		"(Size>1":        7,
+		"Size>1 Dir":     7,
+		`Name=="log`:     6,
+		"Size>1 & Dir>1": 7,
+		"&& Size>1":      0,
+	} {
+		_, err := ParseFilter(expr)
+		var exprErr *ExprError
+		if !errors.As(err, &exprErr) || !errors.Is(err, ErrorExprSyntax) {
+			t.Errorf("%s: expected ExprError, got %v", expr, err)
+			continue
+		}
+		if exprErr.Pos != pos {
+			t.Errorf("%s: expected position %d, got %d (%v)", expr, pos, exprErr.Pos, err)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	tab := New("", BorderNone, nil)
+	tab.VisibleHeader = false
+	tab.AddColumn("Name", WidthAuto, AlignLeft).
+		AddColumn("Size", WidthAuto, AlignRight)
+	tab.AppendData(map[string]interface{}{"Name": "a", "Size": 10})
+	tab.AppendData(map[string]interface{}{"Name": "verylongname", "Size": 5000})
+	tab.AppendRow("c", 2000)
+	if err := tab.FilterExpr("Size>1000"); err != nil {
+		t.Fatal(err)
+	}
+	tab.Filter(func(rec map[string]interface{}) bool {
+		return rec["Name"] != "verylongname"
+	})
+	tab.AddFooter(map[string]interface{}{"Name": func() interface{} { return fmt.Sprintf("-%d", tab.Filtered()) }, "Size": Sum})
+	org := fmt.Sprintf("c    2000%[1]s-2   2000%[1]s", eol.EOL)
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+	if n := tab.Filtered(); n != 2 {
+		t.Errorf("Excepted 2, got %d", n)
+	}
+	if err := tab.FilterExpr("Size>"); err == nil {
+		t.Error("Expected error")
+	}
+
+	tab.ClearFilter()
+	tab.ClearFooter()
+	tab.AppendData(map[string]interface{}{"Name": "nosize"})
+	if err := tab.FilterExpr("Size<100"); err != nil {
+		t.Fatal(err)
+	}
+	if n := tab.Filtered(); n != 3 {
+		t.Errorf("Excepted 3, got %d", n)
+	}
+
+	tab.ClearFilter()
+	if n := tab.Filtered(); n != 0 {
+		t.Errorf("Excepted 0, got %d", n)
+	}
+}
+
+func TestFilterGetter(t *testing.T) {
+	n := 0
+	tab := New("", BorderNone, func() (bool, map[string]interface{}) {
+		n++
+		return n <= 5, map[string]interface{}{"N": n}
+	})
+	tab.VisibleHeader = false
+	tab.AddColumn("N", 1, AlignLeft)
+	tab.Filter(Gt("N", 3)).SortBy("N", true)
+	org := fmt.Sprintf("5%[1]s4%[1]s", eol.EOL)
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+	if n := tab.Filtered(); n != 3 {
+		t.Errorf("Excepted 3, got %d", n)
+	}
+}
+
+func TestFilterHidden(t *testing.T) {
+	tab := New("", BorderNone, nil)
+	tab.VisibleHeader = false
+	tab.AddColumn("N", 1, AlignLeft).
+		AddColumn("Hidden", 1, AlignLeft)
+	tab.Columns.FindByName("Hidden").Visible = false
+	tab.AppendData(map[string]interface{}{"N": 1, "Hidden": "x"})
+	tab.AppendData(map[string]interface{}{"N": 2})
+	//the positional rows have no values of hidden columns
+	tab.AppendRow(3, "x")
+	tab.Filter(Eq("Hidden", "x"))
+	org := fmt.Sprintf("1%[1]s", eol.EOL)
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+}
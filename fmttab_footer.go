@@ -0,0 +1,240 @@
+package fmttab
+
+import (
+	"reflect"
+
+	"github.com/arteev/fmttab/columns"
+	"github.com/arteev/fmttab/style"
+)
+
+//An Aggregator accumulates the values of a column and computes the value of the footer
+type Aggregator interface {
+	Add(v interface{})
+	Result() interface{}
+}
+
+//A sum the aggregator of the sum of numbers. The sum of integers is int64
+type sum struct {
+	total   float64
+	integer int64
+	float   bool
+}
+
+//Sum returns the aggregator of the sum of numbers of the column
+func Sum() Aggregator {
+	return &sum{}
+}
+
+func (s *sum) Add(v interface{}) {
+	if n, ok := integer(v); ok {
+		s.integer += n
+		s.total += float64(n)
+		return
+	}
+	if x, ok := number(v); ok {
+		s.total += x
+		s.float = true
+	}
+}
+
+func (s *sum) Result() interface{} {
+	if s.float {
+		return s.total
+	}
+	return s.integer
+}
+
+type count int
+
+//Count returns the aggregator of the count of values of the column, nil values are not counted
+func Count() Aggregator {
+	return new(count)
+}
+
+func (c *count) Add(v interface{}) {
+	if v != nil {
+		*c++
+	}
+}
+
+func (c *count) Result() interface{} {
+	return int(*c)
+}
+
+//An extremum the aggregator of the least or the greatest value
+type extremum struct {
+	value interface{}
+	sign  int
+}
+
+//Min returns the aggregator of the least value of the column
+func Min() Aggregator {
+	return &extremum{sign: -1}
+}
+
+//Max returns the aggregator of the greatest value of the column
+func Max() Aggregator {
+	return &extremum{sign: 1}
+}
+
+func (e *extremum) Add(v interface{}) {
+	if v == nil {
+		return
+	}
+	if e.value == nil {
+		e.value = v
+		return
+	}
+	if n, ok := compareValues(v, e.value); ok && n*e.sign > 0 {
+		e.value = v
+	}
+}
+
+func (e *extremum) Result() interface{} {
+	return e.value
+}
+
+type avg struct {
+	total float64
+	n     int
+}
+
+//Avg returns the aggregator of the average of numbers of the column
+func Avg() Aggregator {
+	return &avg{}
+}
+
+func (a *avg) Add(v interface{}) {
+	if x, ok := number(v); ok {
+		a.total += x
+		a.n++
+	}
+}
+
+func (a *avg) Result() interface{} {
+	if a.n == 0 {
+		return nil
+	}
+	return a.total / float64(a.n)
+}
+
+//A custom the aggregator collecting the values for the function
+type custom struct {
+	values []interface{}
+	f      func(values []interface{}) interface{}
+}
+
+//Aggregate returns the factory of the aggregator computing f over all values of the column
+func Aggregate(f func(values []interface{}) interface{}) func() Aggregator {
+	return func() Aggregator {
+		return &custom{f: f}
+	}
+}
+
+func (c *custom) Add(v interface{}) {
+	c.values = append(c.values, v)
+}
+
+func (c *custom) Result() interface{} {
+	return c.f(c.values)
+}
+
+//AddFooter adds the row of the footer. The values of the row are bound to the columns by names,
+//the factories of aggregators as Sum, Count, Min, Max, Avg or Aggregate are computed over the records of the table,
+//the functions func() interface{} are called when the footer is written, the other values are written as is.
+//The aggregator passed as the value, e.g. Sum(), is the prototype: each output computes the shallow copy of it,
+//so the maps, slices and pointers held by the aggregator are shared by the outputs. Pass the factory
+//func() Aggregator for aggregators with such state
+func (t *Table) AddFooter(row map[string]interface{}) *Table {
+	t.footer = append(t.footer, row)
+	return t
+}
+
+//ClearFooter removes all rows of the footer
+func (t *Table) ClearFooter() {
+	t.footer = nil
+}
+
+//A footerCell the static value or the aggregator of the footer
+type footerCell struct {
+	value interface{}
+	agg   Aggregator
+	fn    func() interface{}
+}
+
+//result returns the value of the cell of the footer
+func (f footerCell) result() interface{} {
+	switch {
+	case f.agg != nil:
+		return f.agg.Result()
+	case f.fn != nil:
+		return f.fn()
+	}
+	return f.value
+}
+
+//newFooter creates the cells of the footer rows by indexes of visible columns
+func (t *Table) newFooter() [][]footerCell {
+	return t.newAggregates(t.footer)
+}
+
+//newAggregates creates the cells of the rows of aggregates by indexes of visible columns
+func (t *Table) newAggregates(footer []map[string]interface{}) [][]footerCell {
+	rows := make([][]footerCell, 0, len(footer))
+	for _, row := range footer {
+		cells := make([]footerCell, t.columnsvisible.Len())
+		num := 0
+		t.columnsvisible.Visit(func(c *columns.Column) error {
+			switch v := row[c.Name].(type) {
+			case func() Aggregator:
+				cells[num].agg = v()
+			case Aggregator:
+				cells[num].agg = clone(v)
+			case func() interface{}:
+				cells[num].fn = v
+			default:
+				cells[num].value = v
+			}
+			num++
+			return nil
+		})
+		rows = append(rows, cells)
+	}
+	return rows
+}
+
+//clone returns the shallow copy of the aggregator passed to AddFooter as the value
+func clone(agg Aggregator) Aggregator {
+	rv := reflect.ValueOf(agg)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return agg
+	}
+	cp := reflect.New(rv.Elem().Type())
+	cp.Elem().Set(rv.Elem())
+	return cp.Interface().(Aggregator)
+}
+
+//aggregate adds the values of the record to the aggregators of the footer
+func (t *Table) aggregate(footer [][]footerCell, rec record) {
+	for _, row := range footer {
+		num := 0
+		t.columnsvisible.Visit(func(c *columns.Column) error {
+			if row[num].agg != nil {
+				row[num].agg.Add(rec.value(num, c))
+			}
+			num++
+			return nil
+		})
+	}
+}
+
+//aggregateCells sets the cells by the row of aggregates
+func (t *Table) aggregateCells(row []footerCell, cells []Cell, s style.Style) {
+	num := 0
+	t.columnsvisible.Visit(func(c *columns.Column) error {
+		val := row[num].result()
+		cells[num] = Cell{Column: c, Value: val, Text: c.Format(val), Style: c.Style.Merge(s), Span: 1}
+		num++
+		return nil
+	})
+}
@@ -0,0 +1,113 @@
+package fmttab
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/arteev/fmttab/eol"
+)
+
+func TestAggregators(t *testing.T) {
+	values := []interface{}{3, nil, 1.5, "x", uint8(2)}
+	test := []struct {
+		name string
+		agg  func() Aggregator
+		want interface{}
+	}{
+		{"Sum", Sum, 6.5},
+		{"Count", Count, 4},
+		{"Avg", Avg, 6.5 / 3},
+		{"Aggregate", Aggregate(func(v []interface{}) interface{} { return len(v) }), 5},
+	}
+	for _, tt := range test {
+		agg := tt.agg()
+		for _, v := range values {
+			agg.Add(v)
+		}
+		if got := agg.Result(); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	ints := Sum()
+	ints.Add(1)
+	ints.Add(int64(2))
+	if got := ints.Result(); got != int64(3) {
+		t.Errorf("Sum: expected int64 3, got %#v", got)
+	}
+	big := Sum()
+	big.Add(int64(1<<53 + 1))
+	big.Add(uint32(2))
+	if got := big.Result(); got != int64(1<<53+3) {
+		t.Errorf("Sum: expected %d, got %#v", int64(1<<53+3), got)
+	}
+	huge := Sum()
+	huge.Add(uint64(math.MaxUint64))
+	huge.Add(1)
+	if got := huge.Result(); got != float64(math.MaxUint64)+1 {
+		t.Errorf("Sum: expected %v, got %#v", float64(math.MaxUint64)+1, got)
+	}
+	min, max := Min(), Max()
+	for _, v := range []interface{}{5, nil, 2, 9.5} {
+		min.Add(v)
+		max.Add(v)
+	}
+	if min.Result() != 2 || max.Result() != 9.5 {
+		t.Errorf("Min, Max: expected 2, 9.5, got %v, %v", min.Result(), max.Result())
+	}
+	if got := Avg().Result(); got != nil {
+		t.Errorf("Avg: expected nil, got %v", got)
+	}
+}
+
+func TestFooter(t *testing.T) {
+	tab := New("", BorderThin, nil)
+	tab.AddColumn("Name", WidthAuto, AlignLeft).
+		AddColumn("Size", WidthAuto, AlignRight)
+	tab.AppendData(map[string]interface{}{"Name": "a", "Size": 100000})
+	tab.AppendData(map[string]interface{}{"Name": "b", "Size": 200000})
+	tab.AddFooter(map[string]interface{}{"Name": "Total", "Size": Sum}).
+		AddFooter(map[string]interface{}{"Name": "Files", "Size": Count})
+	org := fmt.Sprintf("┌─────┬──────┐%[1]s│Name │  Size│%[1]s├─────┼──────┤%[1]s│a    │100000│%[1]s│b    │200000│%[1]s"+
+		"├─────┼──────┤%[1]s│Total│300000│%[1]s│Files│     2│%[1]s└─────┴──────┘%[1]s", eol.EOL)
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+	//aggregates are computed again on each output
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+
+	//the aggregator passed as the value is copied on each output
+	tab.ClearFooter()
+	tab.AddFooter(map[string]interface{}{"Name": "Total", "Size": Sum()}).
+		AddFooter(map[string]interface{}{"Name": "Files", "Size": Count()})
+	for i := 0; i < 2; i++ {
+		if res := tab.String(); res != org {
+			t.Errorf("Excepted \n%q, got:\n%q", org, res)
+		}
+	}
+
+	tab.ClearFooter()
+	if res := tab.String(); strings.Contains(res, "Total") {
+		t.Errorf("Unexpected footer:\n%s", res)
+	}
+}
+
+func TestFooterStreaming(t *testing.T) {
+	n := 0
+	tab := NewRows("", BorderThin, func() (bool, []interface{}) {
+		n++
+		return n <= 3, []interface{}{n, float64(n) / 2}
+	})
+	tab.AddColumn("N", 4, AlignRight).
+		AddColumn("Half", 4, AlignRight)
+	tab.AddFooter(map[string]interface{}{"N": Max, "Half": Avg})
+	org := fmt.Sprintf("┌────┬────┐%[1]s│   N│Half│%[1]s├────┼────┤%[1]s│   1│ 0.5│%[1]s│   2│   1│%[1]s│   3│ 1.5│%[1]s"+
+		"├────┼────┤%[1]s│   3│   1│%[1]s└────┴────┘%[1]s", eol.EOL)
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+}
@@ -0,0 +1,147 @@
+package fmttab
+
+import (
+	"strings"
+
+	"github.com/arteev/fmttab/columns"
+	"github.com/arteev/fmttab/textwidth"
+)
+
+//A GroupRenderer is a Renderer drawing the groups of records.
+//Other renderers get the headers of groups as rows with the key in the first column
+//and the subtotals as rows
+type GroupRenderer interface {
+	Renderer
+	//Group writes the header of the group spanning all columns
+	Group(key Cell) error
+	//Subtotal writes the row of subtotals of the group
+	Subtotal(cells []Cell) error
+}
+
+//GroupBy groups the consecutive records with equal values of the column. The header of the group is written
+//each time the value changes, sort the table by the column to collect all records of a group.
+//The column can be hidden for the records of maps, the positional rows have no values of hidden columns
+//and they are grouped into one group. The grouping by the unknown column is ignored
+func (t *Table) GroupBy(column string) *Table {
+	t.groupBy = column
+	return t
+}
+
+//AddSubtotal adds the row of subtotals written after each group.
+//The values of the row are the same as the values of AddFooter, aggregates are computed over the records of the group
+func (t *Table) AddSubtotal(row map[string]interface{}) *Table {
+	t.subtotals = append(t.subtotals, row)
+	return t
+}
+
+//ClearGroup removes the grouping and the subtotals
+func (t *Table) ClearGroup() {
+	t.groupBy = ""
+	t.subtotals = nil
+}
+
+//A grouper splits the records into groups by the value of the column
+type grouper struct {
+	t         *Table
+	column    *columns.Column
+	index     int
+	started   bool
+	key       interface{}
+	subtotals [][]footerCell
+}
+
+//newGrouper returns the grouper of the table or nil if the table is not grouped or the column is unknown
+func (t *Table) newGrouper() *grouper {
+	if t.groupBy == "" {
+		return nil
+	}
+	c, index := t.bindColumn(t.groupBy)
+	if c == nil {
+		return nil
+	}
+	return &grouper{t: t, column: c, index: index}
+}
+
+//changed returns true and the key if the record begins the new group
+func (g *grouper) changed(r record) (interface{}, bool) {
+	key := r.value(g.index, g.column)
+	return key, !g.started || sortCompare(key, g.key) != 0
+}
+
+//begin starts the new group with the key
+func (g *grouper) begin(key interface{}) {
+	g.started = true
+	g.key = key
+	g.subtotals = g.t.newAggregates(g.t.subtotals)
+}
+
+//add adds the record to the subtotals of the group
+func (g *grouper) add(r record) {
+	g.t.aggregate(g.subtotals, r)
+}
+
+//groupText returns the text of the header of the group in one line
+func (g *grouper) groupText(key interface{}) string {
+	return strings.Join(textwidth.Lines(g.column.Format(key)), " ")
+}
+
+//group writes the header of the group
+func (g *grouper) group(r Renderer, key interface{}, cells []Cell) error {
+	cell := Cell{Column: g.column, Value: key, Text: g.column.Format(key), Style: g.t.GroupStyle}
+	if gr, ok := r.(GroupRenderer); ok {
+		return gr.Group(cell)
+	}
+	num := 0
+	g.t.columnsvisible.Visit(func(c *columns.Column) error {
+		cells[num] = Cell{Column: c, Span: 1}
+		num++
+		return nil
+	})
+	cells[0].Value, cells[0].Text, cells[0].Style = cell.Value, cell.Text, cell.Style
+	return r.Row(cells)
+}
+
+//subtotal writes the subtotals of the last group
+func (g *grouper) subtotal(r Renderer, cells []Cell) error {
+	if !g.started {
+		return nil
+	}
+	for _, row := range g.subtotals {
+		g.t.aggregateCells(row, cells, g.t.FooterStyle)
+		var err error
+		if gr, ok := r.(GroupRenderer); ok {
+			err = gr.Subtotal(cells)
+		} else {
+			err = r.Row(cells)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//groupRows returns the subtotals of all groups of the stored records and the row with the widest header of groups
+//spanning all columns to fit the groups into the columns
+func (t *Table) groupRows() ([][]footerCell, [][]Cell) {
+	g := t.newGrouper()
+	if g == nil || t.streaming() {
+		return nil, nil
+	}
+	var (
+		subtotals [][]footerCell
+		widest    string
+	)
+	t.visitRecords(func(r record) error {
+		if key, changed := g.changed(r); changed {
+			g.begin(key)
+			subtotals = append(subtotals, g.subtotals...)
+			if text := g.groupText(key); textwidth.String(text) > textwidth.String(widest) {
+				widest = text
+			}
+		}
+		g.add(r)
+		return nil
+	})
+	return subtotals, [][]Cell{{{Column: g.column, Text: widest, Span: t.columnsvisible.Len()}}}
+}
@@ -0,0 +1,80 @@
+package fmttab
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/arteev/fmttab/eol"
+)
+
+func newInventory() *Table {
+	tab := New("", BorderThin, nil)
+	tab.AddColumn("Category", WidthAuto, AlignLeft).
+		AddColumn("Item", WidthAuto, AlignLeft).
+		AddColumn("Qty", WidthAuto, AlignRight)
+	tab.Columns.FindByName("Category").Visible = false
+	tab.AppendData(map[string]interface{}{"Category": "Vegetables", "Item": "carrot", "Qty": 7})
+	tab.AppendData(map[string]interface{}{"Category": "Fruits", "Item": "apple", "Qty": 10})
+	tab.AppendData(map[string]interface{}{"Category": "Fruits", "Item": "pear", "Qty": 5})
+	return tab
+}
+
+func TestGroupBy(t *testing.T) {
+	tab := newInventory()
+	tab.SortBy("Category", false).GroupBy("Category")
+	tab.AddSubtotal(map[string]interface{}{"Item": "total", "Qty": Sum})
+	org := fmt.Sprintf("┌──────┬───┐%[1]s│Item  │Qty│%[1]s├──────┴───┤%[1]s│Fruits    │%[1]s├──────┬───┤%[1]s"+
+		"│apple │ 10│%[1]s│pear  │  5│%[1]s├──────┼───┤%[1]s│total │ 15│%[1]s├──────┴───┤%[1]s"+
+		"│Vegetables│%[1]s├──────┬───┤%[1]s│carrot│  7│%[1]s├──────┼───┤%[1]s│total │  7│%[1]s└──────┴───┘%[1]s", eol.EOL)
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+
+	tab.ClearGroup()
+	tab.ClearSort()
+	tab.GroupBy("Category")
+	tab.VisibleHeader = false
+	tab.CloseEachColumn = true
+	org = fmt.Sprintf("┌──────────┐%[1]s│Vegetables│%[1]s├──────┬───┤%[1]s│carrot│  7│%[1]s├──────┴───┤%[1]s"+
+		"│Fruits    │%[1]s├──────┬───┤%[1]s│apple │ 10│%[1]s├──────┼───┤%[1]s│pear  │  5│%[1]s└──────┴───┘%[1]s", eol.EOL)
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+}
+
+func TestGroupRenderer(t *testing.T) {
+	tab := newInventory()
+	tab.GroupBy("Category").AddSubtotal(map[string]interface{}{"Qty": Count})
+	var org orgRenderer
+	if err := tab.Render(&org); err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("| Item | Qty |%[1]s|-%[1]s| Vegetables |  |%[1]s| carrot | 7 |%[1]s|  | 1 |%[1]s"+
+		"| Fruits |  |%[1]s| apple | 10 |%[1]s| pear | 5 |%[1]s|  | 2 |%[1]s", eol.EOL)
+	if res := org.buf.String(); res != exp {
+		t.Errorf("Excepted \n%q, got:\n%q", exp, res)
+	}
+}
+
+func TestGroupByWidth(t *testing.T) {
+	tab := newInventory()
+	tab.AppendData(map[string]interface{}{"Category": "Very long category name", "Item": "x", "Qty": 1})
+	tab.VisibleHeader = false
+	tab.GroupBy("Category")
+	org := fmt.Sprintf("┌───────────────────────┐%[1]s│Vegetables             │%[1]s├──────┬────────────────┤%[1]s│carrot│               7│%[1]s"+
+		"├──────┴────────────────┤%[1]s│Fruits                 │%[1]s├──────┬────────────────┤%[1]s│apple │              10│%[1]s│pear  │               5│%[1]s"+
+		"├──────┴────────────────┤%[1]s│Very long category name│%[1]s├──────┬────────────────┤%[1]s│x     │               1│%[1]s└──────┴────────────────┘%[1]s", eol.EOL)
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+}
+
+func TestGroupByUnknown(t *testing.T) {
+	tab := newInventory()
+	tab.VisibleHeader = false
+	org := tab.String()
+	tab.GroupBy("Nope").SortBy("Nope", true).AddSubtotal(map[string]interface{}{"Qty": Sum})
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+}
@@ -0,0 +1,148 @@
+package fmttab
+
+import (
+	"bufio"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/arteev/fmttab/columns"
+	"github.com/arteev/fmttab/eol"
+)
+
+//A HTMLOptions options of HTML output
+type HTMLOptions struct {
+	//Class the CSS class of the table element
+	Class string
+	//ColumnClass returns the CSS class of the cells of the column, can be nil
+	ColumnClass func(c *columns.Column) string
+	//RowClass returns the CSS class of the row with index n, can be nil.
+	//The map of the positional row is reused for the next record, so RowClass must not keep it
+	RowClass func(n int, rec map[string]interface{}) string
+}
+
+var htmlAligns = map[columns.Align]string{
+	columns.AlignLeft:    "left",
+	columns.AlignRight:   "right",
+	columns.AlignCenter:  "center",
+	columns.AlignJustify: "justify",
+	columns.AlignDecimal: "right",
+}
+
+//htmlText escapes the text of a cell, line breaks are replaced by <br>
+func htmlText(s string) string {
+	return strings.Replace(html.EscapeString(strings.Replace(s, "\r\n", "\n", -1)), "\n", "<br>", -1)
+}
+
+//htmlAttrs returns the class and the style attributes
+func htmlAttrs(class string, align columns.Align) string {
+	var attrs string
+	if class != "" {
+		attrs += ` class="` + html.EscapeString(class) + `"`
+	}
+	if a, ok := htmlAligns[align]; ok {
+		attrs += ` style="text-align:` + a + `"`
+	}
+	return attrs
+}
+
+//htmlSpan returns the colspan attribute of the cell spanning several columns
+func htmlSpan(span int) string {
+	if span < 2 {
+		return ""
+	}
+	return ` colspan="` + strconv.Itoa(span) + `"`
+}
+
+//WriteHTML writes the table to w as HTML table element.
+//The groups of the header are written as the rows above the captions, the cells of Span are written with colspan
+func (t *Table) WriteHTML(w io.Writer, opts HTMLOptions) (int64, error) {
+	t.columnsvisible = t.Columns.ColumnsVisible()
+	if t.columnsvisible.Len() == 0 {
+		return 0, nil
+	}
+	classes := make([]string, 0, t.columnsvisible.Len())
+	t.columnsvisible.Visit(func(c *columns.Column) error {
+		class := ""
+		if opts.ColumnClass != nil {
+			class = opts.ColumnClass(c)
+		}
+		classes = append(classes, class)
+		return nil
+	})
+
+	cw := &countWriter{w: w}
+	buf := bufio.NewWriter(cw)
+	buf.WriteString("<table" + htmlAttrs(opts.Class, columns.AlignDefault) + ">" + eol.EOL)
+	if t.caption != "" {
+		buf.WriteString("<caption>" + htmlText(t.caption) + "</caption>" + eol.EOL)
+	}
+	if t.VisibleHeader {
+		buf.WriteString("<thead>" + eol.EOL)
+		for _, row := range t.headerRows() {
+			buf.WriteString("<tr>")
+			pos := 0
+			for _, cell := range row {
+				if cell.Text == "" {
+					buf.WriteString("<th" + htmlSpan(cell.Span) + htmlAttrs(classes[pos], columns.AlignDefault) + "></th>")
+				} else {
+					buf.WriteString("<th" + htmlSpan(cell.Span) + htmlAttrs("", cell.Column.Aling) + ">" + htmlText(cell.Text) + "</th>")
+				}
+				pos += cell.Span
+			}
+			buf.WriteString("</tr>" + eol.EOL)
+		}
+		buf.WriteString("<tr>")
+		num := 0
+		t.columnsvisible.Visit(func(c *columns.Column) error {
+			align := c.CaptionAlign
+			if align == columns.AlignDefault {
+				align = c.Aling
+			}
+			if align == columns.AlignDefault {
+				align = columns.AlignLeft
+			}
+			buf.WriteString("<th" + htmlAttrs(classes[num], align) + ">" + htmlText(t.headerText(c)) + "</th>")
+			num++
+			return nil
+		})
+		buf.WriteString("</tr>" + eol.EOL + "</thead>" + eol.EOL)
+	}
+	buf.WriteString("<tbody>" + eol.EOL)
+	n := 0
+	err := t.visitRecords(func(r record) error {
+		class := ""
+		if opts.RowClass != nil {
+			class = opts.RowClass(n, t.recordMap(r, &t.columnsvisible))
+		}
+		n++
+		buf.WriteString("<tr" + htmlAttrs(class, columns.AlignDefault) + ">")
+		num, covered := 0, 0
+		t.columnsvisible.Visit(func(c *columns.Column) error {
+			index := num
+			num++
+			if covered > 0 {
+				covered--
+				return nil
+			}
+			val, _, span := r.cell(index, c)
+			if span > len(classes)-index {
+				span = len(classes) - index
+			}
+			if span > 1 {
+				covered = span - 1
+			}
+			buf.WriteString("<td" + htmlSpan(span) + htmlAttrs(classes[index], c.Aling) + ">" + htmlText(c.Format(val)) + "</td>")
+			return nil
+		})
+		_, err := buf.WriteString("</tr>" + eol.EOL)
+		return err
+	})
+	if err != nil {
+		return cw.n, err
+	}
+	buf.WriteString("</tbody>" + eol.EOL + "</table>" + eol.EOL)
+	err = buf.Flush()
+	return cw.n, err
+}
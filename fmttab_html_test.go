@@ -0,0 +1,97 @@
+package fmttab
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/arteev/fmttab/columns"
+	"github.com/arteev/fmttab/eol"
+)
+
+func TestWriteHTML(t *testing.T) {
+	tab := New("Files <all>", BorderThin, nil)
+	tab.AddColumn("Name", 10, AlignDefault).
+		AddColumn("Size", 10, AlignRight).
+		AddColumn("Hidden", 10, AlignLeft)
+	tab.Columns.FindByName("Hidden").Visible = false
+	tab.Columns.FindByName("Size").CaptionAlign = AlignCenter
+	tab.AppendData(map[string]interface{}{"Name": "a&b\nc", "Size": 10})
+	tab.AppendRow("d", 20)
+	opts := HTMLOptions{
+		Class: "files",
+		ColumnClass: func(c *columns.Column) string {
+			if c.Name == "Size" {
+				return "num"
+			}
+			return ""
+		},
+		RowClass: func(n int, rec map[string]interface{}) string {
+			if rec["Size"] == 20 {
+				return fmt.Sprintf("big row%d", n)
+			}
+			return ""
+		},
+	}
+	org := fmt.Sprintf(`<table class="files">%[1]s`+
+		`<caption>Files &lt;all&gt;</caption>%[1]s`+
+		`<thead>%[1]s<tr><th style="text-align:left">Name</th><th class="num" style="text-align:center">Size</th></tr>%[1]s</thead>%[1]s`+
+		`<tbody>%[1]s`+
+		`<tr><td>a&amp;b<br>c</td><td class="num" style="text-align:right">10</td></tr>%[1]s`+
+		`<tr class="big row1"><td>d</td><td class="num" style="text-align:right">20</td></tr>%[1]s`+
+		`</tbody>%[1]s</table>%[1]s`, eol.EOL)
+	var buf bytes.Buffer
+	n, err := tab.WriteHTML(&buf, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if org != buf.String() {
+		t.Errorf("Excepted \n%s, got:\n%s", org, buf.String())
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("Excepted count write:%d, got: %d", buf.Len(), n)
+	}
+}
+
+func TestWriteHTMLSpan(t *testing.T) {
+	tab := New("", BorderThin, nil)
+	tab.AddColumn("Iface", 5, AlignLeft).
+		AddColumn("RX", 4, AlignRight).
+		AddColumn("TX", 4, AlignRight)
+	tab.AddHeaderGroup(0, "Network", "RX", "TX")
+	tab.AppendRow("lo", Span{Value: "n/a", Columns: 2})
+	org := fmt.Sprintf(`<table>%[1]s<thead>%[1]s`+
+		`<tr><th></th><th colspan="2" style="text-align:center">Network</th></tr>%[1]s`+
+		`<tr><th style="text-align:left">Iface</th><th style="text-align:right">RX</th><th style="text-align:right">TX</th></tr>%[1]s</thead>%[1]s`+
+		`<tbody>%[1]s<tr><td style="text-align:left">lo</td><td colspan="2" style="text-align:right">n/a</td></tr>%[1]s`+
+		`</tbody>%[1]s</table>%[1]s`, eol.EOL)
+	var buf bytes.Buffer
+	if _, err := tab.WriteHTML(&buf, HTMLOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if org != buf.String() {
+		t.Errorf("Excepted \n%s, got:\n%s", org, buf.String())
+	}
+}
+
+func TestWriteHTMLSorted(t *testing.T) {
+	tab := New("", BorderThin, nil)
+	tab.AddColumn("Name", 10, AlignLeft).
+		AddColumn("Size", 10, AlignRight)
+	tab.AppendRow("b", 1)
+	tab.AppendRow("a", 2)
+	tab.SortBy("Size", true)
+	org := fmt.Sprintf(`<table>%[1]s`+
+		`<thead>%[1]s<tr><th style="text-align:left">Name</th><th style="text-align:right">Size %[2]s</th></tr>%[1]s</thead>%[1]s`+
+		`<tbody>%[1]s`+
+		`<tr><td style="text-align:left">a</td><td style="text-align:right">2</td></tr>%[1]s`+
+		`<tr><td style="text-align:left">b</td><td style="text-align:right">1</td></tr>%[1]s`+
+		`</tbody>%[1]s</table>%[1]s`, eol.EOL, SortDesc)
+	var buf bytes.Buffer
+	if _, err := tab.WriteHTML(&buf, HTMLOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if org != buf.String() {
+		t.Errorf("Excepted \n%s, got:\n%s", org, buf.String())
+	}
+}
@@ -0,0 +1,92 @@
+package fmttab
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+
+	"github.com/arteev/fmttab/columns"
+	"github.com/arteev/fmttab/eol"
+)
+
+//A JSONOptions options of JSON output
+type JSONOptions struct {
+	//Caption the objects are keyed by captions of columns instead of names
+	Caption bool
+	//Raw the values are written as is instead of the formatted text of cells
+	Raw bool
+}
+
+//WriteJSON writes the visible columns of the table to w as JSON array of objects.
+//The spans are flattened: the value of Span is written to its first column, the covered columns are written
+//with their own values. The groups of the header are not written
+func (t *Table) WriteJSON(w io.Writer, opts JSONOptions) (int64, error) {
+	return t.writeJSON(w, opts, "[", ",", "]"+eol.EOL)
+}
+
+//WriteNDJSON writes the visible columns of the table to w as newline delimited JSON,
+//one object per line. Records are streamed from the getter of the table
+func (t *Table) WriteNDJSON(w io.Writer, opts JSONOptions) (int64, error) {
+	return t.writeJSON(w, opts, "", eol.EOL, eol.EOL)
+}
+
+func (t *Table) writeJSON(w io.Writer, opts JSONOptions, begin, sep, end string) (int64, error) {
+	t.columnsvisible = t.Columns.ColumnsVisible()
+	keys := make([][]byte, 0, t.columnsvisible.Len())
+	err := t.columnsvisible.Visit(func(c *columns.Column) error {
+		key := c.Name
+		if opts.Caption {
+			key = c.Caption
+		}
+		b, err := json.Marshal(key)
+		keys = append(keys, b)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	cw := &countWriter{w: w}
+	buf := bufio.NewWriter(cw)
+	buf.WriteString(begin)
+	first := true
+	err = t.visitRecords(func(r record) error {
+		if !first {
+			buf.WriteString(sep)
+		}
+		first = false
+		buf.WriteByte('{')
+		num := 0
+		err := t.columnsvisible.Visit(func(c *columns.Column) error {
+			var val interface{}
+			if opts.Raw {
+				val = r.value(num, c)
+			} else {
+				val = c.Format(r.value(num, c))
+			}
+			b, err := json.Marshal(val)
+			if err != nil {
+				return err
+			}
+			if num > 0 {
+				buf.WriteByte(',')
+			}
+			buf.Write(keys[num])
+			buf.WriteByte(':')
+			buf.Write(b)
+			num++
+			return nil
+		})
+		buf.WriteByte('}')
+		return err
+	})
+	if err != nil {
+		return cw.n, err
+	}
+	if first && begin == "" {
+		end = ""
+	}
+	buf.WriteString(end)
+	err = buf.Flush()
+	return cw.n, err
+}
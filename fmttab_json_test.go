@@ -0,0 +1,77 @@
+package fmttab
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/arteev/fmttab/columns"
+	"github.com/arteev/fmttab/eol"
+)
+
+func makeJSONTable() *Table {
+	tab := New("", BorderThin, nil)
+	tab.AddColumn("name", 10, AlignLeft).
+		AddColumn("hidden", 10, AlignLeft).
+		AddNumberColumn("price", 10, columns.NumberFormat{Precision: 2})
+	tab.Columns.FindByName("hidden").Visible = false
+	tab.Columns.FindByName("price").Caption = "Price"
+	tab.AppendData(map[string]interface{}{"name": "a\"b", "hidden": 1, "price": 1.5})
+	tab.AppendRow("c", nil)
+	return tab
+}
+
+func TestWriteJSON(t *testing.T) {
+	test := []struct {
+		opts JSONOptions
+		want string
+	}{
+		{JSONOptions{}, `[{"name":"a\"b","price":"1.50"},{"name":"c","price":""}]` + eol.EOL},
+		{JSONOptions{Raw: true}, `[{"name":"a\"b","price":1.5},{"name":"c","price":null}]` + eol.EOL},
+		{JSONOptions{Raw: true, Caption: true}, `[{"name":"a\"b","Price":1.5},{"name":"c","Price":null}]` + eol.EOL},
+	}
+	for _, tt := range test {
+		var buf bytes.Buffer
+		n, err := makeJSONTable().WriteJSON(&buf, tt.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tt.want != buf.String() {
+			t.Errorf("Excepted \n%s, got:\n%s", tt.want, buf.String())
+		}
+		if n != int64(buf.Len()) {
+			t.Errorf("Excepted count write:%d, got: %d", buf.Len(), n)
+		}
+	}
+
+	var buf bytes.Buffer
+	if _, err := New("", BorderThin, nil).WriteJSON(&buf, JSONOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if org := "[]" + eol.EOL; buf.String() != org {
+		t.Errorf("Excepted %q, got:%q", org, buf.String())
+	}
+	tab := makeJSONTable()
+	tab.AppendRow("chan", make(chan int))
+	if _, err := tab.WriteJSON(&buf, JSONOptions{Raw: true}); err == nil {
+		t.Error("Excepted error")
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	cur := 0
+	tab := NewRows("", BorderThin, func() (bool, []interface{}) {
+		if cur >= 2 {
+			return false, nil
+		}
+		cur++
+		return true, []interface{}{cur}
+	})
+	tab.AddColumn("id", 10, AlignLeft)
+	var buf bytes.Buffer
+	if _, err := tab.WriteNDJSON(&buf, JSONOptions{Raw: true}); err != nil {
+		t.Fatal(err)
+	}
+	if org := "{\"id\":1}" + eol.EOL + "{\"id\":2}" + eol.EOL; org != buf.String() {
+		t.Errorf("Excepted \n%q, got:\n%q", org, buf.String())
+	}
+}
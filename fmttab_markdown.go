@@ -0,0 +1,78 @@
+package fmttab
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/arteev/fmttab/columns"
+	"github.com/arteev/fmttab/eol"
+)
+
+var markdownReplacer = strings.NewReplacer("|", "\\|", "\r\n", "<br>", "\n", "<br>")
+
+//markdownDelimiter returns the cell of the delimiter row of the column
+func markdownDelimiter(c *columns.Column) string {
+	switch c.Aling {
+	case columns.AlignDefault, columns.AlignLeft:
+		return ":---"
+	case columns.AlignRight, columns.AlignDecimal:
+		return "---:"
+	case columns.AlignCenter:
+		return ":---:"
+	}
+	return "---"
+}
+
+//WriteMarkdown writes the table as GitHub Flavored Markdown table to w.
+//The caption of the table is written as a paragraph before the table.
+//The header row is always written because GFM tables require it.
+//GFM tables have no spans: the value of Span is written in its first column, the covered columns are written
+//with their own values, and the groups of the header are not written
+func (t *Table) WriteMarkdown(w io.Writer) (int64, error) {
+	t.columnsvisible = t.Columns.ColumnsVisible()
+	if t.columnsvisible.Len() == 0 {
+		return 0, nil
+	}
+	cw := &countWriter{w: w}
+	buf := bufio.NewWriter(cw)
+	if t.caption != "" {
+		buf.WriteString(markdownReplacer.Replace(t.caption))
+		buf.WriteString(eol.EOL + eol.EOL)
+	}
+	var captions, delimiters []string
+	t.columnsvisible.Visit(func(c *columns.Column) error {
+		captions = append(captions, markdownReplacer.Replace(c.Caption))
+		delimiters = append(delimiters, markdownDelimiter(c))
+		return nil
+	})
+	writeMarkdownRow(buf, captions)
+	writeMarkdownRow(buf, delimiters)
+
+	cells := make([]string, t.columnsvisible.Len())
+	err := t.visitRecords(func(r record) error {
+		num := 0
+		t.columnsvisible.Visit(func(c *columns.Column) error {
+			cells[num] = markdownReplacer.Replace(c.Format(r.value(num, c)))
+			num++
+			return nil
+		})
+		return writeMarkdownRow(buf, cells)
+	})
+	if err != nil {
+		return cw.n, err
+	}
+	err = buf.Flush()
+	return cw.n, err
+}
+
+func writeMarkdownRow(buf *bufio.Writer, cells []string) error {
+	buf.WriteString("|")
+	for _, cell := range cells {
+		buf.WriteString(" ")
+		buf.WriteString(cell)
+		buf.WriteString(" |")
+	}
+	_, err := buf.WriteString(eol.EOL)
+	return err
+}
@@ -0,0 +1,42 @@
+package fmttab
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/arteev/fmttab/eol"
+)
+
+func TestWriteMarkdown(t *testing.T) {
+	tab := New("Files", BorderThin, nil)
+	tab.AddColumn("Name", 10, AlignLeft).
+		AddColumn("Size", 10, AlignRight).
+		AddColumn("Mode", 10, AlignCenter).
+		AddColumn("Hidden", 10, AlignLeft).
+		AddColumn("Note", 10, AlignDefault)
+	tab.Columns.FindByName("Hidden").Visible = false
+	tab.Columns.FindByName("Size").Formatter = func(v interface{}) string {
+		return fmt.Sprintf("%v B", v)
+	}
+	tab.AppendData(map[string]interface{}{
+		"Name":   "a|b",
+		"Size":   10,
+		"Mode":   "rw",
+		"Hidden": "x",
+		"Note":   "line1\nline2",
+	})
+	tab.AppendRow("c", 20)
+	org := fmt.Sprintf("Files%[1]s%[1]s| Name | Size | Mode | Note |%[1]s| :--- | ---: | :---: | :--- |%[1]s| a\\|b | 10 B | rw | line1<br>line2 |%[1]s| c | 20 B |  |  |%[1]s", eol.EOL)
+	var buf bytes.Buffer
+	n, err := tab.WriteMarkdown(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if org != buf.String() {
+		t.Errorf("Excepted \n%q, got:\n%q", org, buf.String())
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("Excepted count write:%d, got: %d", buf.Len(), n)
+	}
+}
@@ -0,0 +1,58 @@
+package fmttab
+
+import "github.com/arteev/fmttab/columns"
+
+//A merger tracks the texts of the previous record to merge the repeated values of columns with MergeRepeated.
+//The merging stops at the first column with MergeRepeated whose value changes,
+//so the merged cells of a column never cross the change of a column on the left
+type merger struct {
+	texts  []string
+	valid  []bool
+	broken bool
+}
+
+//newMerger returns the merger of the visible columns or nil if no column merges the repeated values
+func newMerger(cols *columns.Columns) *merger {
+	found := false
+	cols.Visit(func(c *columns.Column) error {
+		found = found || c.MergeRepeated
+		return nil
+	})
+	if !found {
+		return nil
+	}
+	return &merger{texts: make([]string, cols.Len()), valid: make([]bool, cols.Len())}
+}
+
+//reset forgets the previous record, the next record is not merged
+func (m *merger) reset() {
+	for i := range m.valid {
+		m.valid[i] = false
+	}
+}
+
+//row starts the next record
+func (m *merger) row() {
+	m.broken = false
+}
+
+//merged reports whether the cell of the column with index repeats the cell of the previous record
+func (m *merger) merged(index, span int, c *columns.Column, text string) bool {
+	if !c.MergeRepeated {
+		return false
+	}
+	same := !m.broken && span == 1 && m.valid[index] && m.texts[index] == text
+	m.texts[index], m.valid[index] = text, span == 1
+	if !same {
+		m.broken = true
+	}
+	return same
+}
+
+//cover marks the column with index covered by the span of the cell on the left
+func (m *merger) cover(index int, c *columns.Column) {
+	m.valid[index] = false
+	if c.MergeRepeated {
+		m.broken = true
+	}
+}
@@ -0,0 +1,54 @@
+package fmttab
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/arteev/fmttab/eol"
+)
+
+func newPods() *Table {
+	tab := New("", BorderThin, nil)
+	tab.AddColumn("NS", WidthAuto, AlignLeft).
+		AddColumn("Pod", WidthAuto, AlignLeft).
+		AddColumn("Node", WidthAuto, AlignLeft)
+	tab.Columns.Get(0).MergeRepeated = true
+	tab.Columns.Get(2).MergeRepeated = true
+	tab.AppendRow("kube", "dns", "n1")
+	tab.AppendRow("kube", "proxy", "n1")
+	tab.AppendRow("web", "app", "n1")
+	tab.AppendRow("web", "db", "n2")
+	return tab
+}
+
+func TestMergeRepeated(t *testing.T) {
+	tab := newPods()
+	org := fmt.Sprintf("┌────┬─────┬────┐%[1]s│NS  │Pod  │Node│%[1]s├────┼─────┼────┤%[1]s"+
+		"│kube│dns  │n1  │%[1]s│    │proxy│    │%[1]s│web │app  │n1  │%[1]s│    │db   │n2  │%[1]s└────┴─────┴────┘%[1]s", eol.EOL)
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+
+	tab.CloseEachColumn = true
+	org = fmt.Sprintf("┌────┬─────┬────┐%[1]s│NS  │Pod  │Node│%[1]s├────┼─────┼────┤%[1]s"+
+		"│kube│dns  │n1  │%[1]s│    ├─────┤    │%[1]s│    │proxy│    │%[1]s├────┼─────┼────┤%[1]s"+
+		"│web │app  │n1  │%[1]s│    ├─────┼────┤%[1]s│    │db   │n2  │%[1]s└────┴─────┴────┘%[1]s", eol.EOL)
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+}
+
+func TestMergeRepeatedRenderer(t *testing.T) {
+	tab := newPods()
+	tab.AppendRow(Span{Value: "web", Columns: 2}, nil, "n2")
+	tab.AppendRow("web", "db", "n2")
+	var org orgRenderer
+	if err := tab.Render(&org); err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("| NS | Pod | Node |%[1]s|-%[1]s| kube | dns | n1 |%[1]s|  | proxy |  |%[1]s| web | app | n1 |%[1]s"+
+		"|  | db | n2 |%[1]s| web | n2 |%[1]s| web | db | n2 |%[1]s", eol.EOL)
+	if res := org.buf.String(); res != exp {
+		t.Errorf("Excepted \n%q, got:\n%q", exp, res)
+	}
+}
@@ -0,0 +1,184 @@
+package fmttab
+
+import (
+	"github.com/arteev/fmttab/columns"
+	"github.com/arteev/fmttab/style"
+)
+
+//A RowGetter functional type for table data as rows of values.
+//The values are bound to the visible columns by index
+type RowGetter func() (bool, []interface{})
+
+//A record the data of a row of the table: the values by names of columns
+//or the values by indexes of visible columns
+type record struct {
+	data map[string]interface{}
+	row  []interface{}
+}
+
+//value returns the value of the column c with index i among visible columns
+func (r record) value(i int, c *columns.Column) interface{} {
+	v, _, _ := r.cell(i, c)
+	return v
+}
+
+//cell returns the value of the column c with index i among visible columns,
+//the style of the cell if the value is wrapped by style.Value and the count of columns if the value is wrapped by Span
+func (r record) cell(i int, c *columns.Column) (interface{}, style.Style, int) {
+	var v interface{}
+	if r.row == nil {
+		v = r.data[c.Name]
+	} else if i >= 0 && i < len(r.row) {
+		v = r.row[i]
+	}
+	return unwrap(v)
+}
+
+//unwrap returns the value wrapped by style.Value and Span, its style and its count of columns
+func unwrap(v interface{}) (interface{}, style.Style, int) {
+	var (
+		s    style.Style
+		span = 1
+	)
+	for {
+		switch x := v.(type) {
+		case style.Value:
+			v, s = x.Value, s.Merge(x.Style)
+		case Span:
+			v, span = x.Value, x.Columns
+		default:
+			return v, s, span
+		}
+	}
+}
+
+//A storedRow the row added by AppendRow, it is written before the record of Data with index at
+type storedRow struct {
+	at     int
+	values []interface{}
+}
+
+//AppendRow adds the row of values to the table. The values are bound to the visible columns by index.
+//The rows and the records added by AppendData are written in the order of the calls
+func (t *Table) AppendRow(values ...interface{}) *Table {
+	if values == nil {
+		values = []interface{}{}
+	}
+	t.rows = append(t.rows, storedRow{at: len(t.Data), values: values})
+	return t
+}
+
+//visitStored bypasses the records stored in the table in the order of AppendData and AppendRow calls
+func (t *Table) visitStored(f func(r record) error) error {
+	rows := t.rows
+	for i, data := range t.Data {
+		for len(rows) > 0 && rows[0].at <= i {
+			if err := f(record{row: rows[0].values}); err != nil {
+				return err
+			}
+			rows = rows[1:]
+		}
+		if err := f(record{data: data}); err != nil {
+			return err
+		}
+	}
+	for _, row := range rows {
+		if err := f(record{row: row.values}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//streaming reports whether the records are read from the getter of the table
+func (t *Table) streaming() bool {
+	return t.dataget != nil || t.rowget != nil
+}
+
+//visitRecords bypasses the records of the getter of the table or the stored records if the table has no getter.
+//The records are filtered and sorted by the table
+func (t *Table) visitRecords(f func(r record) error) error {
+	t.filtered = 0
+	if len(t.sortKeys) > 0 {
+		return t.visitSorted(f)
+	}
+	return t.visitSource(t.matched(f))
+}
+
+//visitSource bypasses the records in the order of the getter or the stored records
+func (t *Table) visitSource(f func(r record) error) error {
+	switch {
+	case t.dataget != nil:
+		for {
+			ok, data := t.dataget()
+			if !ok {
+				return nil
+			}
+			if err := f(record{data: data}); err != nil {
+				return err
+			}
+		}
+	case t.rowget != nil:
+		for {
+			ok, row := t.rowget()
+			if !ok {
+				return nil
+			}
+			if row == nil {
+				row = []interface{}{}
+			}
+			if err := f(record{row: row}); err != nil {
+				return err
+			}
+		}
+	}
+	return t.visitStored(f)
+}
+
+//toMap returns the values of the record by names of visible columns.
+//The values of the positional row are put to dst if it is not nil, the map dst is cleared before
+func (r record) toMap(cols *columns.Columns, dst map[string]interface{}) map[string]interface{} {
+	if r.row == nil {
+		for _, v := range r.data {
+			switch v.(type) {
+			case style.Value, Span:
+				return unstyle(r.data)
+			}
+		}
+		return r.data
+	}
+	if dst == nil {
+		dst = make(map[string]interface{}, len(r.row))
+	}
+	for k := range dst {
+		delete(dst, k)
+	}
+	num := 0
+	cols.Visit(func(c *columns.Column) error {
+		dst[c.Name] = r.value(num, c)
+		num++
+		return nil
+	})
+	return dst
+}
+
+//recordMap returns the values of the record by names of columns cols for predicates and styles of rows.
+//The map of the positional row is reused by the next call
+func (t *Table) recordMap(r record, cols *columns.Columns) map[string]interface{} {
+	if r.row == nil {
+		return r.toMap(cols, nil)
+	}
+	if t.rowMap == nil {
+		t.rowMap = make(map[string]interface{}, cols.Len())
+	}
+	return r.toMap(cols, t.rowMap)
+}
+
+//unstyle returns the copy of data with the values unwrapped from style.Value and Span
+func unstyle(data map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		res[k], _, _ = unwrap(v)
+	}
+	return res
+}
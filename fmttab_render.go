@@ -0,0 +1,203 @@
+package fmttab
+
+import (
+	"github.com/arteev/fmttab/columns"
+	"github.com/arteev/fmttab/style"
+)
+
+//A Cell the value of a cell passed to a Renderer
+type Cell struct {
+	Column *columns.Column
+	//Value the raw value of the cell
+	Value interface{}
+	//Text the formatted text of the cell
+	Text string
+	//Span the count of columns covered by the cell, 0 means one column.
+	//The cells covered by the span are omitted from the row
+	Span int
+	//Merged the cell repeats the value of the cell above in the column with MergeRepeated, its text is empty
+	Merged bool
+	//Style the style of the cell: the styles of the column, the row, the rules and the value merged.
+	//The style of the row consists of Zebra and RowStyle of the table
+	Style style.Style
+}
+
+//A Renderer outputs the table in some format.
+//Table.Render calls BeginTable first, then Header if VisibleHeader is set,
+//Row for each record with Separator between rows if CloseEachColumn is set,
+//Footer for each row of the footer and EndTable at the end.
+//Header is called for each row of the groups of the header before the captions of columns.
+//If the table has no visible columns only BeginTable and EndTable are called.
+//The slice of cells is valid only during the call
+type Renderer interface {
+	BeginTable(t *Table) error
+	Header(cells []Cell) error
+	Row(cells []Cell) error
+	Separator() error
+	Footer(cells []Cell) error
+	EndTable() error
+}
+
+//Render outputs the table by the renderer
+func (t *Table) Render(r Renderer) error {
+	t.columnsvisible = t.Columns.ColumnsVisible()
+	t.bs = t.BorderStyle().resolve()
+	cntCols := t.columnsvisible.Len()
+	footer := t.newFooter()
+	streaming := t.streaming()
+	if !streaming && len(footer) > 0 {
+		//the stored records are aggregated before rendering to fit the footer into the columns
+		t.visitFiltered(func(rec record) error {
+			t.aggregate(footer, rec)
+			return nil
+		})
+	}
+	var headerRows [][]Cell
+	if cntCols > 0 {
+		subtotals, groups := t.groupRows()
+		if err := t.adjustmentWidth(append(footer, subtotals...)); err != nil {
+			return err
+		}
+		t.fitHeaderRows(groups)
+		if t.VisibleHeader {
+			headerRows = t.headerRows()
+			t.fitHeaderRows(headerRows)
+		}
+	}
+	if err := r.BeginTable(t); err != nil {
+		return err
+	}
+	if cntCols == 0 {
+		return r.EndTable()
+	}
+
+	for _, row := range headerRows {
+		if err := r.Header(row); err != nil {
+			return err
+		}
+	}
+	cells := make([]Cell, cntCols)
+	if t.VisibleHeader {
+		num := 0
+		t.columnsvisible.Visit(func(c *columns.Column) error {
+			cells[num] = Cell{Column: c, Value: c.Caption, Text: t.headerText(c), Style: t.HeaderStyle.Merge(c.CaptionStyle), Span: 1}
+			num++
+			return nil
+		})
+		if err := r.Header(cells); err != nil {
+			return err
+		}
+	}
+
+	rules := t.ruleSet()
+	ruleStyles := make([]style.Style, cntCols)
+	groups := t.newGrouper()
+	merges := newMerger(&t.columnsvisible)
+	firstrow := true
+	n := 0
+	err := t.visitRecords(func(rec record) error {
+		if groups != nil {
+			if key, changed := groups.changed(rec); changed {
+				if err := groups.subtotal(r, cells); err != nil {
+					return err
+				}
+				groups.begin(key)
+				if err := groups.group(r, key, cells); err != nil {
+					return err
+				}
+				firstrow = true
+				if merges != nil {
+					merges.reset()
+				}
+			}
+			groups.add(rec)
+		}
+		if !firstrow && t.CloseEachColumn {
+			if err := r.Separator(); err != nil {
+				return err
+			}
+		}
+		firstrow = false
+		if streaming {
+			t.aggregate(footer, rec)
+		}
+		var rowStyle style.Style
+		if n%2 == 1 {
+			rowStyle = t.Zebra
+		}
+		if t.RowStyle != nil || rules.Len() > 0 {
+			data := t.recordMap(rec, &t.columnsvisible)
+			if t.RowStyle != nil {
+				rowStyle = rowStyle.Merge(t.RowStyle(n, data))
+			}
+			for i := range ruleStyles {
+				ruleStyles[i] = style.Style{}
+			}
+			rules.apply(data, &rowStyle, ruleStyles)
+		}
+		n++
+		row := cells[:0]
+		num, covered := 0, 0
+		if merges != nil {
+			merges.row()
+		}
+		t.columnsvisible.Visit(func(c *columns.Column) error {
+			index := num
+			num++
+			if covered > 0 {
+				covered--
+				if merges != nil {
+					merges.cover(index, c)
+				}
+				return nil
+			}
+			val, cellStyle, span := rec.cell(index, c)
+			if span < 1 {
+				span = 1
+			}
+			if span > cntCols-index {
+				span = cntCols - index
+			}
+			covered = span - 1
+			text := c.Format(val)
+			merged := merges != nil && merges.merged(index, span, c, text)
+			if merged {
+				text = ""
+			}
+			row = append(row, Cell{Column: c, Value: val, Text: text, Style: c.Style.Merge(rowStyle).Merge(ruleStyles[index]).Merge(cellStyle), Span: span, Merged: merged})
+			return nil
+		})
+		return r.Row(row)
+	})
+	if err != nil {
+		return err
+	}
+	if groups != nil {
+		if err := groups.subtotal(r, cells); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range footer {
+		t.aggregateCells(row, cells, t.FooterStyle)
+		if err := r.Footer(cells); err != nil {
+			return err
+		}
+	}
+	return r.EndTable()
+}
+
+//Caption returns the caption of the table
+func (t *Table) Caption() string {
+	return t.caption
+}
+
+//SetCaption sets the caption of the table
+func (t *Table) SetCaption(caption string) {
+	t.caption = caption
+}
+
+//VisibleColumns returns the visible columns of the table
+func (t *Table) VisibleColumns() columns.Columns {
+	return t.Columns.ColumnsVisible()
+}
@@ -0,0 +1,135 @@
+package fmttab
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/arteev/fmttab/eol"
+)
+
+//orgRenderer writes the table as org-mode table
+type orgRenderer struct {
+	buf bytes.Buffer
+}
+
+func (o *orgRenderer) BeginTable(t *Table) error {
+	if t.Caption() != "" {
+		o.buf.WriteString("#+CAPTION: " + t.Caption() + eol.EOL)
+	}
+	return nil
+}
+
+func (o *orgRenderer) Header(cells []Cell) error {
+	o.Row(cells)
+	o.buf.WriteString("|-" + eol.EOL)
+	return nil
+}
+
+func (o *orgRenderer) Row(cells []Cell) error {
+	texts := make([]string, len(cells))
+	for i, cell := range cells {
+		texts[i] = cell.Text
+	}
+	o.buf.WriteString("| " + strings.Join(texts, " | ") + " |" + eol.EOL)
+	return nil
+}
+
+func (o *orgRenderer) Separator() error {
+	o.buf.WriteString("|-" + eol.EOL)
+	return nil
+}
+
+func (o *orgRenderer) Footer(cells []Cell) error {
+	return o.Row(cells)
+}
+
+func (o *orgRenderer) EndTable() error {
+	return nil
+}
+
+func TestRender(t *testing.T) {
+	tab := New("Files", BorderThin, nil)
+	tab.AddColumn("Name", WidthAuto, AlignLeft).
+		AddColumn("Size", WidthAuto, AlignRight).
+		AddColumn("Hidden", WidthAuto, AlignLeft)
+	tab.Columns.FindByName("Hidden").Visible = false
+	tab.AppendData(map[string]interface{}{"Name": "a.txt", "Size": 10, "Hidden": "x"})
+	tab.AppendData(map[string]interface{}{"Name": "b.txt", "Size": 2.5})
+	tab.CloseEachColumn = true
+
+	var org orgRenderer
+	if err := tab.Render(&org); err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("#+CAPTION: Files%[1]s| Name | Size |%[1]s|-%[1]s| a.txt | 10 |%[1]s|-%[1]s| b.txt | 2.5 |%[1]s", eol.EOL)
+	if res := org.buf.String(); res != exp {
+		t.Errorf("Excepted \n%q, got:\n%q", exp, res)
+	}
+
+	tab.VisibleHeader = false
+	org.buf.Reset()
+	if err := tab.Render(&org); err != nil {
+		t.Fatal(err)
+	}
+	exp = fmt.Sprintf("#+CAPTION: Files%[1]s| a.txt | 10 |%[1]s|-%[1]s| b.txt | 2.5 |%[1]s", eol.EOL)
+	if res := org.buf.String(); res != exp {
+		t.Errorf("Excepted \n%q, got:\n%q", exp, res)
+	}
+}
+
+//failRenderer fails on the row with the index n
+type failRenderer struct {
+	orgRenderer
+	n int
+}
+
+var errRender = errors.New("Render failed")
+
+func (f *failRenderer) Row(cells []Cell) error {
+	if f.n == 0 {
+		return errRender
+	}
+	f.n--
+	return f.orgRenderer.Row(cells)
+}
+
+func TestRenderError(t *testing.T) {
+	tab := New("", BorderThin, nil)
+	tab.AddColumn("Name", WidthAuto, AlignLeft)
+	for i := 0; i < 3; i++ {
+		tab.AppendData(map[string]interface{}{"Name": i})
+	}
+	r := &failRenderer{n: 1}
+	if err := tab.Render(r); err != errRender {
+		t.Errorf("Excepted %v, got %v", errRender, err)
+	}
+	if n, err := tab.WriteTo(failWriter{}); err == nil || n != -1 {
+		t.Errorf("Excepted error and -1, got %v, %d", err, n)
+	}
+}
+
+func TestBoxRenderer(t *testing.T) {
+	tab := New("Table", BorderThin, nil)
+	tab.AddColumn("Name", 4, AlignLeft)
+	tab.AppendData(map[string]interface{}{"Name": "a"})
+	var buf bytes.Buffer
+	box := NewBoxRenderer(&buf)
+	if err := tab.Render(box); err != nil {
+		t.Fatal(err)
+	}
+	if exp := tab.String(); buf.String() != exp {
+		t.Errorf("Excepted \n%q, got:\n%q", exp, buf.String())
+	}
+	if box.Written() != int64(buf.Len()) {
+		t.Errorf("Excepted %d, got %d", buf.Len(), box.Written())
+	}
+}
+
+type failWriter struct{}
+
+func (failWriter) Write(p []byte) (int, error) {
+	return 0, errRender
+}
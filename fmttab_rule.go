@@ -0,0 +1,181 @@
+package fmttab
+
+import (
+	"sort"
+
+	"github.com/arteev/fmttab/columns"
+	"github.com/arteev/fmttab/style"
+)
+
+//A Predicate reports whether the record matches. The record contains the values by names of columns.
+//The positional rows of AppendRow and RowGetter are bound to the visible columns only,
+//so for them the values of hidden columns are nil. Their map is reused for the next record, so do not keep it
+type Predicate func(rec map[string]interface{}) bool
+
+//A Rule applies the style to the records matching the predicate.
+//The style of the rule can turn off the attributes of the rules with lower priority by NoBold, NoDim and NoUnderline
+type Rule struct {
+	Predicate Predicate
+	Style     style.Style
+	//Column the name of the column of styled cells, empty means the whole row
+	Column string
+	//Priority the rules with greater priority are applied over the rules with lower priority,
+	//the rules with equal priority are applied in the order of addition
+	Priority int
+}
+
+//AddRule adds the rule styling the rows matching the predicate
+func (t *Table) AddRule(pred Predicate, s style.Style) *Rule {
+	rule := &Rule{Predicate: pred, Style: s}
+	t.rules = append(t.rules, rule)
+	return rule
+}
+
+//AddCellRule adds the rule styling the cells of the column in the rows matching the predicate.
+//It returns *ColumnError if the table has no column
+func (t *Table) AddCellRule(column string, pred Predicate, s style.Style) (*Rule, error) {
+	if t.Columns.FindByName(column) == nil {
+		return nil, &ColumnError{Name: column, Err: columns.ErrorNotFound}
+	}
+	rule := t.AddRule(pred, s)
+	rule.Column = column
+	return rule, nil
+}
+
+//ClearRules removes all rules of the table
+func (t *Table) ClearRules() {
+	t.rules = nil
+}
+
+//A ruleSet the rules of the table ordered by priority bound with the visible columns
+type ruleSet struct {
+	rules []*Rule
+	//index the indexes of the columns of rules among visible columns, -1 for rules of rows
+	index []int
+}
+
+func (t *Table) ruleSet() ruleSet {
+	var rs ruleSet
+	for _, rule := range t.rules {
+		index := -1
+		if rule.Column != "" {
+			num := 0
+			index = -2
+			t.columnsvisible.Visit(func(c *columns.Column) error {
+				if c.Name == rule.Column {
+					index = num
+				}
+				num++
+				return nil
+			})
+		}
+		if index == -2 || rule.Predicate == nil {
+			continue
+		}
+		rs.rules = append(rs.rules, rule)
+		rs.index = append(rs.index, index)
+	}
+	sort.Stable(rs)
+	return rs
+}
+
+func (rs ruleSet) Len() int           { return len(rs.rules) }
+func (rs ruleSet) Less(i, j int) bool { return rs.rules[i].Priority < rs.rules[j].Priority }
+func (rs ruleSet) Swap(i, j int) {
+	rs.rules[i], rs.rules[j] = rs.rules[j], rs.rules[i]
+	rs.index[i], rs.index[j] = rs.index[j], rs.index[i]
+}
+
+//apply merges the styles of the matching rules into the style of the row and the styles of cells
+func (rs ruleSet) apply(rec map[string]interface{}, row *style.Style, cells []style.Style) {
+	for i, rule := range rs.rules {
+		if !rule.Predicate(rec) {
+			continue
+		}
+		if index := rs.index[i]; index >= 0 {
+			cells[index] = cells[index].Merge(rule.Style)
+		} else {
+			*row = row.Merge(rule.Style)
+		}
+	}
+}
+
+//Eq matches the records with the value of the column equal to v
+func Eq(column string, v interface{}) Predicate {
+	return compareWith(column, v, func(n int) bool { return n == 0 })
+}
+
+//Ne matches the records with the value of the column not equal to v
+func Ne(column string, v interface{}) Predicate {
+	return Not(Eq(column, v))
+}
+
+//Gt matches the records with the value of the column greater than v. Nil values are not ordered,
+//so Gt, Ge, Lt and Le do not match the records with missing values
+func Gt(column string, v interface{}) Predicate {
+	return orderWith(column, v, func(n int) bool { return n > 0 })
+}
+
+//Ge matches the records with the value of the column greater than or equal to v
+func Ge(column string, v interface{}) Predicate {
+	return orderWith(column, v, func(n int) bool { return n >= 0 })
+}
+
+//Lt matches the records with the value of the column less than v
+func Lt(column string, v interface{}) Predicate {
+	return orderWith(column, v, func(n int) bool { return n < 0 })
+}
+
+//Le matches the records with the value of the column less than or equal to v
+func Le(column string, v interface{}) Predicate {
+	return orderWith(column, v, func(n int) bool { return n <= 0 })
+}
+
+//compareWith matches the records with the value of the column comparable with v
+//and the result of comparison satisfying ok
+func compareWith(column string, v interface{}, ok func(n int) bool) Predicate {
+	return func(rec map[string]interface{}) bool {
+		n, comparable := compareValues(rec[column], v)
+		return comparable && ok(n)
+	}
+}
+
+//orderWith matches the records with the value of the column ordered with v
+//and the result of comparison satisfying ok. Nil values are not ordered
+func orderWith(column string, v interface{}, ok func(n int) bool) Predicate {
+	compare := compareWith(column, v, ok)
+	return func(rec map[string]interface{}) bool {
+		return v != nil && rec[column] != nil && compare(rec)
+	}
+}
+
+//And matches the records matching all predicates
+func And(preds ...Predicate) Predicate {
+	return func(rec map[string]interface{}) bool {
+		for _, p := range preds {
+			if !p(rec) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+//Or matches the records matching any of predicates
+func Or(preds ...Predicate) Predicate {
+	return func(rec map[string]interface{}) bool {
+		for _, p := range preds {
+			if p(rec) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+//Not matches the records not matching the predicate
+func Not(pred Predicate) Predicate {
+	return func(rec map[string]interface{}) bool {
+		return !pred(rec)
+	}
+}
@@ -0,0 +1,104 @@
+package fmttab
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/arteev/fmttab/columns"
+	"github.com/arteev/fmttab/eol"
+	"github.com/arteev/fmttab/style"
+)
+
+func TestPredicates(t *testing.T) {
+	now := time.Now()
+	rec := map[string]interface{}{
+		"Name":   "file",
+		"Size":   uint64(2048),
+		"Ratio":  0.5,
+		"Dir":    false,
+		"Time":   now,
+		"Status": "FAIL",
+	}
+	test := []struct {
+		name string
+		pred Predicate
+		want bool
+	}{
+		{"Eq string", Eq("Name", "file"), true},
+		{"Eq number", Eq("Size", 2048), true},
+		{"Eq float", Eq("Ratio", float32(0.5)), true},
+		{"Eq bool", Eq("Dir", false), true},
+		{"Eq missing", Eq("Missing", "x"), false},
+		{"Eq nil", Eq("Missing", nil), true},
+		{"Ne", Ne("Status", "OK"), true},
+		{"Gt", Gt("Size", 1024), true},
+		{"Gt string", Gt("Size", "1024"), false},
+		{"Ge", Ge("Size", 2048), true},
+		{"Lt", Lt("Ratio", 1), true},
+		{"Le", Le("Ratio", 0.1), false},
+		{"Lt missing", Lt("Missing", 100), false},
+		{"Ge nil", Ge("Size", nil), false},
+		{"Lt time", Lt("Time", now.Add(time.Second)), true},
+		{"And", And(Gt("Size", 1024), Eq("Dir", false)), true},
+		{"And false", And(Gt("Size", 1024), Eq("Dir", true)), false},
+		{"Or", Or(Eq("Status", "OK"), Eq("Status", "FAIL")), true},
+		{"Not", Not(Eq("Status", "FAIL")), false},
+	}
+	for _, tt := range test {
+		if got := tt.pred(rec); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestRules(t *testing.T) {
+	tab := New("", BorderNone, nil)
+	tab.ColorMode = style.ModeAlways
+	tab.AddColumn("Name", 4, AlignLeft).
+		AddColumn("Size", 4, AlignRight).
+		AddColumn("Status", 4, AlignLeft)
+	tab.VisibleHeader = false
+	tab.AddRule(Eq("Status", "FAIL"), style.Style{FG: style.Red}).Priority = 1
+	tab.AddRule(Ne("Status", "OK"), style.Style{FG: style.Yellow, Bold: true})
+	if _, err := tab.AddCellRule("Size", Gt("Size", 1<<30), style.Style{BG: style.Yellow}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tab.AddCellRule("Hidden", Gt("Size", 0), style.Style{BG: style.Blue}); !errors.Is(err, columns.ErrorNotFound) {
+		t.Errorf("Excepted %v, got %v", columns.ErrorNotFound, err)
+	}
+	tab.AppendRow("a", 1, "OK")
+	tab.AppendRow("b", 2, "FAIL")
+	tab.AppendData(map[string]interface{}{"Name": "c", "Size": int64(2 << 30), "Status": "OK"})
+
+	red, yellow := "\x1b[1;31m", "\x1b[43m"
+	org := fmt.Sprintf("a       1 OK  %[1]s"+
+		"%[2]sb   \x1b[0m %[2]s   2\x1b[0m %[2]sFAIL\x1b[0m%[1]s"+
+		"c    %[3]s21..\x1b[0m OK  %[1]s", eol.EOL, red, yellow)
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+
+	tab.ClearRules()
+	tab.ColorMode = style.ModeNever
+	org = fmt.Sprintf("a       1 OK  %[1]sb       2 FAIL%[1]sc    21.. OK  %[1]s", eol.EOL)
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+}
+
+func TestRulesTurnOff(t *testing.T) {
+	tab := New("", BorderNone, nil)
+	tab.ColorMode = style.ModeAlways
+	tab.VisibleHeader = false
+	tab.AddColumn("Name", 4, AlignLeft)
+	tab.AddRule(Ne("Name", ""), style.Style{Bold: true, Underline: true})
+	tab.AddRule(Eq("Name", "b"), style.Style{NoBold: true}).Priority = 1
+	tab.AppendRow("a")
+	tab.AppendRow("b")
+	org := fmt.Sprintf("\x1b[1;4ma   \x1b[0m%[1]s\x1b[4mb   \x1b[0m%[1]s", eol.EOL)
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+}
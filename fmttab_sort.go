@@ -0,0 +1,136 @@
+package fmttab
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/arteev/fmttab/columns"
+)
+
+//Indicators of the sorted columns in the header
+var (
+	SortAsc  = "▲"
+	SortDesc = "▼"
+)
+
+//A sortKey the key of sorting of records
+type sortKey struct {
+	column string
+	desc   bool
+}
+
+//SortBy adds the key of sorting of records by the column. The records are sorted when the table is written,
+//the next keys order the records with equal values of the previous keys. Sorting is stable.
+//The records of the getter are read before the output, so the getter must not reuse the maps.
+//The column can be hidden for the records of maps, the positional rows have no values of hidden columns.
+//The keys of unknown columns are ignored
+func (t *Table) SortBy(column string, desc bool) *Table {
+	t.sortKeys = append(t.sortKeys, sortKey{column: column, desc: desc})
+	return t
+}
+
+//ClearSort removes all keys of sorting
+func (t *Table) ClearSort() {
+	t.sortKeys = nil
+}
+
+//sortIndicator returns the indicator of sorting of the column or the empty string if the column is not sorted
+func (t *Table) sortIndicator(c *columns.Column) string {
+	for _, key := range t.sortKeys {
+		if key.column != c.Name {
+			continue
+		}
+		if key.desc {
+			return SortDesc
+		}
+		return SortAsc
+	}
+	return ""
+}
+
+//headerText returns the caption of the column with the indicator of sorting
+func (t *Table) headerText(c *columns.Column) string {
+	if ind := t.sortIndicator(c); ind != "" {
+		return c.Caption + " " + ind
+	}
+	return c.Caption
+}
+
+//visitSorted reads all matching records and bypasses them in the order of the keys of sorting
+func (t *Table) visitSorted(f func(r record) error) error {
+	var records []record
+	t.visitSource(t.matched(func(r record) error {
+		records = append(records, r)
+		return nil
+	}))
+
+	type boundKey struct {
+		sortKey
+		column *columns.Column
+		index  int
+	}
+	keys := make([]boundKey, 0, len(t.sortKeys))
+	for _, key := range t.sortKeys {
+		c, index := t.bindColumn(key.column)
+		if c == nil {
+			continue
+		}
+		keys = append(keys, boundKey{sortKey: key, column: c, index: index})
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		for _, key := range keys {
+			a, b := records[i].value(key.index, key.column), records[j].value(key.index, key.column)
+			var n int
+			if key.column.Compare != nil {
+				n = key.column.Compare(a, b)
+			} else {
+				n = sortCompare(a, b)
+			}
+			if n == 0 {
+				continue
+			}
+			if key.desc {
+				return n > 0
+			}
+			return n < 0
+		}
+		return false
+	})
+
+	for _, r := range records {
+		if err := f(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//bindColumn returns the column by name and its index among visible columns or -1 for the hidden column.
+//The values of the hidden column are read from the maps of records. It returns nil for the unknown column
+func (t *Table) bindColumn(name string) (*columns.Column, int) {
+	var (
+		column *columns.Column
+		index  = -1
+		num    = 0
+	)
+	t.columnsvisible.Visit(func(c *columns.Column) error {
+		if c.Name == name {
+			column, index = c, num
+		}
+		num++
+		return nil
+	})
+	if column == nil {
+		column = t.Columns.FindByName(name)
+	}
+	return column, index
+}
+
+//sortCompare compares the values by types, the values of different types are compared by their text
+func sortCompare(a, b interface{}) int {
+	if n, ok := compareValues(a, b); ok {
+		return n
+	}
+	return naturalCompare(fmt.Sprint(a), fmt.Sprint(b))
+}
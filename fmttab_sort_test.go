@@ -0,0 +1,88 @@
+package fmttab
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arteev/fmttab/eol"
+)
+
+func TestNaturalCompare(t *testing.T) {
+	test := []struct {
+		a, b string
+		want int
+	}{
+		{"file2", "file10", -1},
+		{"file10", "file2", 1},
+		{"file02", "file2", -1},
+		{"file2", "file2", 0},
+		{"a", "b", -1},
+		{"a10b", "a10a", 1},
+		{"10", "9", 1},
+		{"x", "x1", -1},
+	}
+	for _, tt := range test {
+		if got := naturalCompare(tt.a, tt.b); got != tt.want {
+			t.Errorf("naturalCompare(%q,%q): expected %d, got %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	tab := New("", BorderNone, nil)
+	tab.AddColumn("Name", WidthAuto, AlignLeft).
+		AddColumn("Size", WidthAuto, AlignRight).
+		AddColumn("Dir", WidthAuto, AlignLeft)
+	tab.Columns.FindByName("Dir").Visible = false
+	tab.AppendData(map[string]interface{}{"Name": "file10", "Size": 1, "Dir": false})
+	tab.AppendData(map[string]interface{}{"Name": "file2", "Size": 2.5, "Dir": true})
+	tab.AppendData(map[string]interface{}{"Name": "file1", "Size": 1, "Dir": false})
+	tab.AppendRow("file3", nil)
+
+	tab.SortBy("Size", true).SortBy("Name", false)
+	org := fmt.Sprintf("Name ▲ Size ▼%[1]sfile2     2.5%[1]sfile1       1%[1]sfile10      1%[1]sfile3        %[1]s", eol.EOL)
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+
+	tab.ClearSort()
+	tab.SortBy("Dir", true)
+	org = fmt.Sprintf("Name   Size%[1]sfile2   2.5%[1]sfile10    1%[1]sfile1     1%[1]sfile3      %[1]s", eol.EOL)
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+
+	tab.ClearSort()
+	tab.Columns.FindByName("Name").Compare = func(a, b interface{}) int {
+		return len(fmt.Sprint(a)) - len(fmt.Sprint(b))
+	}
+	tab.SortBy("Name", false)
+	res := tab.String()
+	if i, j := strings.Index(res, "file10"), strings.Index(res, "file3"); i < j {
+		t.Errorf("Expected file10 after file3:\n%s", res)
+	}
+}
+
+func TestSortGetter(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	times := []time.Time{now.Add(time.Hour), now, now.Add(-time.Hour)}
+	n := 0
+	tab := NewRows("", BorderNone, func() (bool, []interface{}) {
+		if n == len(times) {
+			return false, nil
+		}
+		n++
+		return true, []interface{}{times[n-1].Format("15:04"), times[n-1]}
+	})
+	tab.VisibleHeader = false
+	tab.AddColumn("Text", 5, AlignLeft).
+		AddColumn("Time", 5, AlignLeft)
+	tab.Columns.FindByName("Time").Formatter = func(v interface{}) string { return "" }
+	tab.SortBy("Time", false)
+	org := fmt.Sprintf("23:00      %[1]s00:00      %[1]s01:00      %[1]s", eol.EOL)
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+}
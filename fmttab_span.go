@@ -0,0 +1,168 @@
+package fmttab
+
+import (
+	"github.com/arteev/fmttab/columns"
+	"github.com/arteev/fmttab/style"
+	"github.com/arteev/fmttab/textwidth"
+)
+
+//A Span the value of the cell spanning several visible columns of the row.
+//The values of the covered columns are ignored
+type Span struct {
+	Value interface{}
+	//Columns the count of columns covered by the cell
+	Columns int
+}
+
+//A HeaderGroup the caption spanning several columns above their captions
+type HeaderGroup struct {
+	Caption string
+	//Columns the names of the columns of the group
+	Columns []string
+	//Align alignment of the caption, AlignDefault means AlignCenter
+	Align columns.Align
+	Style style.Style
+	level int
+}
+
+//AddHeaderGroup adds the caption spanning the columns to the header of the table.
+//The level 0 is the row above the captions of columns, the level 1 is the row above it and so on.
+//The hidden columns are skipped, the group is split if its visible columns are not consecutive
+func (t *Table) AddHeaderGroup(level int, caption string, names ...string) *HeaderGroup {
+	g := &HeaderGroup{Caption: caption, Columns: names, level: level}
+	t.headerGroups = append(t.headerGroups, g)
+	return g
+}
+
+//ClearHeaderGroups removes all groups of the header
+func (t *Table) ClearHeaderGroups() {
+	t.headerGroups = nil
+}
+
+//headerGroup returns the group of the column at the level or nil
+func (t *Table) headerGroup(level int, name string) *HeaderGroup {
+	for _, g := range t.headerGroups {
+		if g.level != level {
+			continue
+		}
+		for _, n := range g.Columns {
+			if n == name {
+				return g
+			}
+		}
+	}
+	return nil
+}
+
+//headerRows returns the rows of the groups of the header from the top level down to the level 0
+func (t *Table) headerRows() [][]Cell {
+	levels := -1
+	for _, g := range t.headerGroups {
+		if g.level > levels {
+			levels = g.level
+		}
+	}
+	var rows [][]Cell
+	for level := levels; level >= 0; level-- {
+		var (
+			row  []Cell
+			prev *HeaderGroup
+		)
+		t.columnsvisible.Visit(func(c *columns.Column) error {
+			g := t.headerGroup(level, c.Name)
+			if g != nil && g == prev {
+				row[len(row)-1].Span++
+				return nil
+			}
+			prev = g
+			if g == nil {
+				row = append(row, Cell{Column: c, Span: 1})
+				return nil
+			}
+			align := g.Align
+			if align == columns.AlignDefault {
+				align = columns.AlignCenter
+			}
+			gc := &columns.Column{Name: c.Name, Caption: g.Caption, Aling: align, CaptionAlign: align, Visible: true}
+			row = append(row, Cell{Column: gc, Value: g.Caption, Text: g.Caption, Style: t.HeaderStyle.Merge(g.Style), Span: 1})
+			return nil
+		})
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+//fitHeaderRows widens the last auto sized column of the spans of the header rows to fit their captions
+func (t *Table) fitHeaderRows(rows [][]Cell) {
+	widths := t.columnWidths()
+	for _, row := range rows {
+		pos := 0
+		for _, cell := range row {
+			t.fitSpan(widths, pos, cell.Span, textwidth.String(cell.Text))
+			pos += cell.Span
+		}
+	}
+}
+
+//fitSpan widens the last auto sized column of the cell spanning count columns from the column with index pos
+//to fit the text of width, widths are the widths of the visible columns and are updated
+func (t *Table) fitSpan(widths []int, pos, count, width int) {
+	need := width - spanWidth(widths, pos, count, textwidth.String(t.bs.Get(BKVertical)))
+	for i := pos + count - 1; i >= pos && need > 0; i-- {
+		c := t.columnsvisible.Get(i)
+		if c.IsAutoSize() || t.autoSize > 0 {
+			c.MaxLen = c.GetWidth() + need
+			widths[i] = c.MaxLen
+			need = 0
+		}
+	}
+}
+
+//columnWidths returns the widths of the visible columns
+func (t *Table) columnWidths() []int {
+	widths := make([]int, 0, t.columnsvisible.Len())
+	t.columnsvisible.Visit(func(c *columns.Column) error {
+		widths = append(widths, c.GetWidth())
+		return nil
+	})
+	return widths
+}
+
+//spanWidth returns the width of the cell spanning count columns from the column with index pos,
+//vw is the width of the vertical line between columns
+func spanWidth(widths []int, pos, count, vw int) int {
+	if count < 1 {
+		count = 1
+	}
+	if pos+count > len(widths) {
+		count = len(widths) - pos
+	}
+	width := 0
+	for _, w := range widths[pos : pos+count] {
+		width += w
+	}
+	return width + vw*(count-1)
+}
+
+//boundaries returns for each junction of columns whether a cell of the row ends there, the slice bounds is reused
+func boundaries(bounds []bool, cells []Cell, count int) []bool {
+	if cap(bounds) < count-1 {
+		bounds = make([]bool, count-1)
+	}
+	bounds = bounds[:count-1]
+	for i := range bounds {
+		bounds[i] = false
+	}
+	pos := 0
+	for _, cell := range cells {
+		span := cell.Span
+		if span < 1 {
+			span = 1
+		}
+		pos += span
+		if pos-1 < len(bounds) {
+			bounds[pos-1] = true
+		}
+	}
+	return bounds
+}
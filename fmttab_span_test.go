@@ -0,0 +1,56 @@
+package fmttab
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/arteev/fmttab/eol"
+)
+
+func TestHeaderGroup(t *testing.T) {
+	tab := New("", BorderThin, nil)
+	tab.AddColumn("Iface", WidthAuto, AlignLeft).
+		AddColumn("RX", 4, AlignRight).
+		AddColumn("TX", 4, AlignRight)
+	tab.AddHeaderGroup(0, "Network", "RX", "TX")
+	tab.AppendRow("eth0", 10, 20)
+	tab.AppendRow("lo", Span{Value: "n/a", Columns: 2})
+	tab.AppendRow(Span{Value: "total", Columns: 9})
+	org := fmt.Sprintf("┌─────┬─────────┐%[1]s│     │ Network │%[1]s├─────┼────┬────┤%[1]s"+
+		"│Iface│  RX│  TX│%[1]s├─────┼────┼────┤%[1]s│eth0 │  10│  20│%[1]s│lo   │      n/a│%[1]s│total          │%[1]s"+
+		"└───────────────┘%[1]s", eol.EOL)
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+}
+
+func TestHeaderGroupLevels(t *testing.T) {
+	tab := New("", BorderThin, nil)
+	tab.AddColumn("A", 1, AlignLeft).
+		AddColumn("B", 1, AlignLeft).
+		AddColumn("C", WidthAuto, AlignLeft)
+	tab.AddHeaderGroup(1, "Wide caption", "A", "B", "C")
+	tab.AddHeaderGroup(0, "AB", "A", "B")
+	tab.CloseEachColumn = true
+	tab.AppendData(map[string]interface{}{"A": Span{Value: "x", Columns: 2}, "C": "y"})
+	tab.AppendData(map[string]interface{}{"A": 1, "B": 2, "C": 3})
+	org := fmt.Sprintf("┌────────────┐%[1]s│Wide caption│%[1]s├───┬────────┤%[1]s│AB │        │%[1]s├─┬─┼────────┤%[1]s"+
+		"│A│B│C       │%[1]s├─┴─┼────────┤%[1]s│x  │y       │%[1]s├─┬─┼────────┤%[1]s│1│2│3       │%[1]s└─┴─┴────────┘%[1]s", eol.EOL)
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+}
+
+func TestDataSpanAutoWidth(t *testing.T) {
+	tab := New("", BorderThin, nil)
+	tab.AddColumn("Name", WidthAuto, AlignLeft).
+		AddColumn("Size", 4, AlignRight).
+		AddColumn("Mode", 4, AlignLeft)
+	tab.AppendRow("a", 1, "rw")
+	tab.AppendRow(Span{Value: "merged summary cell", Columns: 3})
+	org := fmt.Sprintf("┌─────────┬────┬────┐%[1]s│Name     │Size│Mode│%[1]s├─────────┼────┼────┤%[1]s"+
+		"│a        │   1│rw  │%[1]s│merged summary cell│%[1]s└───────────────────┘%[1]s", eol.EOL)
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+}
@@ -0,0 +1,259 @@
+package fmttab
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/arteev/fmttab/columns"
+)
+
+//TagName the key of struct tags with the options of columns:
+//
+//	`fmttab:"name,width=10,align=right,omit"`
+//
+//The name of a column defaults to the name of the field, omit or "-" skips the field
+const TagName = "fmttab"
+
+//Errors
+var (
+	ErrorNotStruct = errors.New("Value is not a struct")
+	ErrorNotSlice  = errors.New("Value is not a slice")
+)
+
+//A structField the column bound with a field of the struct
+type structField struct {
+	index []int
+	field string
+	name  string
+	width int
+	align columns.Align
+	depth int
+}
+
+var (
+	structCacheMu sync.RWMutex
+	structCache   = make(map[reflect.Type][]structField)
+)
+
+//FromStructs creates the columns by the fields of the elements of slice
+//and appends the elements to the data of the table.
+//The elements of slice are structs or pointers to structs
+func (t *Table) FromStructs(slice interface{}) error {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return ErrorNotSlice
+	}
+	elem := v.Type().Elem()
+	for elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	fields, err := getStructFields(elem)
+	if err != nil {
+		return err
+	}
+	if err := t.addStructColumns(fields); err != nil {
+		return err
+	}
+	for i := 0; i < v.Len(); i++ {
+		t.appendStruct(v.Index(i), fields)
+	}
+	return nil
+}
+
+//AppendStruct appends the struct or the pointer to struct to the data of the table.
+//The columns of fields missing in the table are created
+func (t *Table) AppendStruct(s interface{}) error {
+	v := reflect.ValueOf(s)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ErrorNotStruct
+		}
+		v = v.Elem()
+	}
+	fields, err := getStructFields(v.Type())
+	if err != nil {
+		return err
+	}
+	if err := t.addStructColumns(fields); err != nil {
+		return err
+	}
+	t.appendStruct(v, fields)
+	return nil
+}
+
+func (t *Table) addStructColumns(fields []structField) error {
+	for _, f := range fields {
+		if t.Columns.FindByName(f.name) != nil {
+			continue
+		}
+		if err := t.TryAddColumn(f.name, f.width, f.align); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Table) appendStruct(v reflect.Value, fields []structField) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	rec := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		rec[f.name] = fieldValue(v, f.index)
+	}
+	t.AppendData(rec)
+}
+
+//fieldValue returns the value of the nested field, nil pointers give nil
+func fieldValue(v reflect.Value, index []int) interface{} {
+	for i, x := range index {
+		if i > 0 {
+			if v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					return nil
+				}
+				v = v.Elem()
+			}
+		}
+		v = v.Field(x)
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	return v.Interface()
+}
+
+//getStructFields returns the cached columns of the struct type
+func getStructFields(typ reflect.Type) ([]structField, error) {
+	if typ.Kind() != reflect.Struct {
+		return nil, ErrorNotStruct
+	}
+	structCacheMu.RLock()
+	fields, ok := structCache[typ]
+	structCacheMu.RUnlock()
+	if ok {
+		return fields, nil
+	}
+	all, err := collectFields(typ, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	//the field of the outer struct hides the fields of embedded structs
+	best := make(map[string]int)
+	for _, f := range all {
+		if d, ok := best[f.field]; !ok || f.depth < d {
+			best[f.field] = f.depth
+		}
+	}
+	for _, f := range all {
+		if d, ok := best[f.field]; ok && d == f.depth {
+			fields = append(fields, f)
+			delete(best, f.field)
+		}
+	}
+	structCacheMu.Lock()
+	structCache[typ] = fields
+	structCacheMu.Unlock()
+	return fields, nil
+}
+
+func collectFields(typ reflect.Type, index []int, depth int) ([]structField, error) {
+	var res []structField
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		tag := sf.Tag.Get(TagName)
+		if tag == "-" || (sf.PkgPath != "" && !sf.Anonymous) {
+			continue
+		}
+		fieldIndex := append(append([]int(nil), index...), i)
+		ft := sf.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if sf.Anonymous && ft.Kind() == reflect.Struct && tag == "" {
+			nested, err := collectFields(ft, fieldIndex, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, nested...)
+			continue
+		}
+		if sf.PkgPath != "" {
+			continue
+		}
+		f := structField{
+			index: fieldIndex,
+			field: sf.Name,
+			name:  sf.Name,
+			align: columns.AlignLeft,
+			width: columns.WidthAuto,
+			depth: depth,
+		}
+		omit, err := parseTag(tag, &f)
+		if err != nil {
+			return nil, fmt.Errorf("Field %s: %v", sf.Name, err)
+		}
+		if !omit {
+			res = append(res, f)
+		}
+	}
+	return res, nil
+}
+
+//parseTag reads the options of the column from the tag, returns true if the field is omitted
+func parseTag(tag string, f *structField) (bool, error) {
+	if tag == "" {
+		return false, nil
+	}
+	opts := strings.Split(tag, ",")
+	if opts[0] != "" {
+		f.name = opts[0]
+	}
+	for _, opt := range opts[1:] {
+		key, val := opt, ""
+		if i := strings.IndexByte(opt, '='); i >= 0 {
+			key, val = opt[:i], opt[i+1:]
+		}
+		switch key {
+		case "omit":
+			return true, nil
+		case "width":
+			if val == "auto" {
+				f.width = columns.WidthAuto
+				continue
+			}
+			width, err := strconv.Atoi(val)
+			if err != nil {
+				return false, fmt.Errorf("invalid width %q", val)
+			}
+			f.width = width
+		case "align":
+			align, ok := aligns[val]
+			if !ok {
+				return false, fmt.Errorf("invalid align %q", val)
+			}
+			f.align = align
+		default:
+			return false, fmt.Errorf("unknown option %q", key)
+		}
+	}
+	return false, nil
+}
+
+var aligns = map[string]columns.Align{
+	"left":    columns.AlignLeft,
+	"right":   columns.AlignRight,
+	"center":  columns.AlignCenter,
+	"justify": columns.AlignJustify,
+	"decimal": columns.AlignDecimal,
+}
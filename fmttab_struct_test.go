@@ -0,0 +1,70 @@
+package fmttab
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/arteev/fmttab/eol"
+)
+
+type testBase struct {
+	ID   int `fmttab:",width=4,align=right"`
+	Name string
+}
+
+type testItem struct {
+	testBase
+	Name    string  `fmttab:"Title,width=6"`
+	Price   float64 `fmttab:"Cost,align=decimal"`
+	Comment *string
+	Secret  string `fmttab:"-"`
+	Hidden  bool   `fmttab:",omit"`
+	private int
+}
+
+func TestFromStructs(t *testing.T) {
+	comment := "note"
+	items := []*testItem{
+		{testBase: testBase{ID: 1}, Name: "apple", Price: 1.5, Comment: &comment},
+		{testBase: testBase{ID: 20}, Name: "orange", Price: 10.25},
+		nil,
+	}
+	tab := New("", BorderThin, nil)
+	if err := tab.FromStructs(items); err != nil {
+		t.Fatal(err)
+	}
+	if tab.Columns.Len() != 4 {
+		t.Fatalf("Excepted 4 columns, got %d", tab.Columns.Len())
+	}
+	org := fmt.Sprintf("┌────┬──────┬─────┬───────┐%[1]s│  ID│Title │ Cost│Comment│%[1]s├────┼──────┼─────┼───────┤%[1]s│   1│apple │ 1.5 │note   │%[1]s│  20│orange│10.25│       │%[1]s└────┴──────┴─────┴───────┘%[1]s", eol.EOL)
+	res := tab.String()
+	if org != res {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+
+	if err := tab.AppendStruct(testItem{Name: "kiwi"}); err != nil {
+		t.Fatal(err)
+	}
+	if tab.CountData() != 3 {
+		t.Errorf("Excepted 3, got:%d", tab.CountData())
+	}
+}
+
+func TestFromStructsErrors(t *testing.T) {
+	tab := New("", BorderThin, nil)
+	if err := tab.FromStructs(testItem{}); err != ErrorNotSlice {
+		t.Errorf("Excepted %v, got:%v", ErrorNotSlice, err)
+	}
+	if err := tab.FromStructs([]int{1}); err != ErrorNotStruct {
+		t.Errorf("Excepted %v, got:%v", ErrorNotStruct, err)
+	}
+	if err := tab.AppendStruct((*testItem)(nil)); err != ErrorNotStruct {
+		t.Errorf("Excepted %v, got:%v", ErrorNotStruct, err)
+	}
+	type invalid struct {
+		A int `fmttab:",width=x"`
+	}
+	if err := tab.AppendStruct(invalid{}); err == nil {
+		t.Error("Excepted error")
+	}
+}
@@ -2,12 +2,15 @@ package fmttab
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/arteev/fmttab/columns"
 	"github.com/arteev/fmttab/eol"
+	"github.com/arteev/fmttab/style"
 )
 
 func TestCallAndOut(t *testing.T) {
@@ -252,3 +255,280 @@ func TestWithoutHeader(t *testing.T) {
 		t.Errorf("Excepted \n%q, got:\n%q", org, res)
 	}
 }
+
+func TestWideChars(t *testing.T) {
+	tab := New("Table", BorderThin, nil)
+	tab.AddColumn("Column1", WidthAuto, AlignLeft)
+	tab.AddColumn("C2", 4, AlignRight)
+	org := fmt.Sprintf("Table%[1]s┌───────┬────┐%[1]s│Column1│  C2│%[1]s├───────┼────┤%[1]s│日本語 │  日│%[1]s│e\u0301\u0301      │日..│%[1]s└───────┴────┘%[1]s", eol.EOL)
+	tab.AppendData(map[string]interface{}{
+		"Column1": "日本語",
+		"C2":      "日",
+	})
+	tab.AppendData(map[string]interface{}{
+		"Column1": "e\u0301\u0301",
+		"C2":      "日本語",
+	})
+	res := tab.String()
+	if org != res {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+}
+
+func TestTruncateColumn(t *testing.T) {
+	tab := New("", BorderThin, nil)
+	tab.VisibleHeader = false
+	tab.AddColumn("Path", 14, AlignLeft).
+		AddColumn("Name", 5, AlignLeft).
+		AddColumn("Tail", 5, AlignLeft)
+	path := tab.Columns.FindByName("Path")
+	path.Truncate = TruncateMiddle
+	path.Ellipsis = "…"
+	tab.Columns.FindByName("Tail").Truncate = TruncateStart
+	tab.AppendData(map[string]interface{}{
+		"Path": "/usr/local/src/file.go",
+		"Name": "Русский",
+		"Tail": "Русский",
+	})
+	org := fmt.Sprintf("┌──────────────┬─────┬─────┐%[1]s│/usr/lo…ile.go│Рус..│..кий│%[1]s└──────────────┴─────┴─────┘%[1]s", eol.EOL)
+	res := tab.String()
+	if org != res {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+}
+
+func TestMultiLine(t *testing.T) {
+	tab := New("", BorderThin, nil)
+	tab.AddColumn("Word", 10, AlignLeft).
+		AddColumn("Lines", 6, AlignRight).
+		AddColumn("One", 7, AlignLeft)
+	tab.Columns.FindByName("Word").Wrap = WrapWord
+	lines := tab.Columns.FindByName("Lines")
+	lines.Wrap = WrapNewline
+	lines.MaxLines = 2
+	tab.AppendData(map[string]interface{}{
+		"Word":  "the quick brown fox",
+		"Lines": "1\n22\n333",
+		"One":   "a\nb",
+	})
+	org := fmt.Sprintf("┌──────────┬──────┬───────┐%[1]s│Word      │ Lines│One    │%[1]s├──────────┼──────┼───────┤%[1]s│the quick │     1│a b    │%[1]s│brown fox │  22..│       │%[1]s└──────────┴──────┴───────┘%[1]s", eol.EOL)
+	res := tab.String()
+	if org != res {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+}
+
+func TestAlignDecimal(t *testing.T) {
+	tab := New("", BorderThin, nil)
+	tab.AddColumn("Price", WidthAuto, AlignDecimal).
+		AddColumn("Name", 8, AlignCenter)
+	tab.Columns.FindByName("Name").CaptionAlign = AlignLeft
+	tab.AppendData(map[string]interface{}{"Price": 1.5, "Name": "one"})
+	tab.AppendData(map[string]interface{}{"Price": 1234, "Name": "two"})
+	tab.AppendData(map[string]interface{}{"Price": 0.125, "Name": "three"})
+	org := fmt.Sprintf("┌────────┬────────┐%[1]s│   Price│Name    │%[1]s├────────┼────────┤%[1]s│   1.5  │  one   │%[1]s│1234    │  two   │%[1]s│   0.125│ three  │%[1]s└────────┴────────┘%[1]s", eol.EOL)
+	res := tab.String()
+	if org != res {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+}
+
+func TestNumberColumn(t *testing.T) {
+	tab := New("", BorderThin, nil)
+	tab.AddNumberColumn("Size", WidthAuto, columns.NumberFormat{
+		Precision: PrecisionAuto,
+		Units:     columns.UnitsIEC,
+	}).AddNumberColumn("Sum", WidthAuto, columns.NumberFormat{
+		Precision: 2,
+		Grouping:  true,
+		Locale:    columns.LocaleEN,
+	})
+	tab.AppendData(map[string]interface{}{"Size": 100, "Sum": 1234.5})
+	tab.AppendData(map[string]interface{}{"Size": 1536, "Sum": 7})
+	org := fmt.Sprintf("┌────────┬────────┐%[1]s│    Size│     Sum│%[1]s├────────┼────────┤%[1]s│100  B  │1,234.50│%[1]s│  1.5KiB│    7.00│%[1]s└────────┴────────┘%[1]s", eol.EOL)
+	res := tab.String()
+	if org != res {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+}
+
+func TestFormatter(t *testing.T) {
+	tab := New("", BorderThin, nil)
+	tab.VisibleHeader = false
+	tab.AddColumn("Ok", WidthAuto, AlignLeft)
+	tab.Columns.FindByName("Ok").Formatter = func(v interface{}) string {
+		if v == true {
+			return "yes, it is"
+		}
+		return "no"
+	}
+	tab.AppendData(map[string]interface{}{"Ok": true})
+	tab.AppendData(map[string]interface{}{"Ok": false})
+	org := fmt.Sprintf("┌──────────┐%[1]s│yes, it is│%[1]s│no        │%[1]s└──────────┘%[1]s", eol.EOL)
+	res := tab.String()
+	if org != res {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+}
+
+func TestRows(t *testing.T) {
+	rows := [][]interface{}{
+		{1, "one"},
+		{2},
+	}
+	cur := 0
+	tab := NewRows("", BorderThin, func() (bool, []interface{}) {
+		if cur >= len(rows) {
+			return false, nil
+		}
+		cur++
+		return true, rows[cur-1]
+	})
+	tab.AddColumn("ID", 3, AlignRight).
+		AddColumn("Hidden", 3, AlignRight).
+		AddColumn("NAME", WidthAuto, AlignLeft)
+	tab.Columns.FindByName("Hidden").Visible = false
+	org := fmt.Sprintf("┌───┬────┐%[1]s│ ID│NAME│%[1]s├───┼────┤%[1]s│  1│one │%[1]s│  2│    │%[1]s└───┴────┘%[1]s", eol.EOL)
+	res := tab.String()
+	if org != res {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+
+	tab2 := New("", BorderThin, nil)
+	tab2.AddColumn("ID", 3, AlignRight).
+		AddColumn("NAME", WidthAuto, AlignLeft)
+	tab2.AppendData(map[string]interface{}{"ID": 1, "NAME": "one"})
+	tab2.AppendRow(2)
+	if tab2.CountData() != 2 {
+		t.Errorf("Excepted 2, got:%d", tab2.CountData())
+	}
+	res = tab2.String()
+	if org != res {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+
+	tab3 := New("", BorderThin, nil)
+	tab3.AddColumn("ID", 3, AlignRight).
+		AddColumn("NAME", WidthAuto, AlignLeft)
+	tab3.AppendRow(1, "one")
+	tab3.AppendData(map[string]interface{}{"ID": 2})
+	tab3.AppendRow(3)
+	tab3.AppendData(map[string]interface{}{"ID": 4})
+	org = fmt.Sprintf("┌───┬────┐%[1]s│ ID│NAME│%[1]s├───┼────┤%[1]s│  1│one │%[1]s│  2│    │%[1]s│  3│    │%[1]s│  4│    │%[1]s└───┴────┘%[1]s", eol.EOL)
+	if res = tab3.String(); org != res {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+
+	tab2.ClearData()
+	if tab2.CountData() != 0 {
+		t.Errorf("Excepted 0, got:%d", tab2.CountData())
+	}
+}
+
+func TestColumnErrors(t *testing.T) {
+	tab := New("", BorderThin, nil)
+	if err := tab.TryAddColumn("ID", 10, AlignLeft); err != nil {
+		t.Fatal(err)
+	}
+	test := map[error]error{
+		tab.TryAddColumn("ID", 10, AlignLeft):                                  columns.ErrorAlreadyExists,
+		tab.TryAddColumn("", 10, AlignLeft):                                    columns.ErrorEmptyName,
+		tab.TryAddColumn("N", -1, AlignLeft):                                   columns.ErrorInvalidWidth,
+		tab.AddColumns(&columns.Column{Name: "A"}, &columns.Column{Name: "A"}): columns.ErrorAlreadyExists,
+		tab.AddColumns(&columns.Column{Name: "A"}, nil):                        columns.ErrorNilColumn,
+	}
+	for err, want := range test {
+		if _, ok := err.(*ColumnError); !ok {
+			t.Errorf("Excepted *ColumnError, got %T", err)
+		}
+		if !errors.Is(err, want) {
+			t.Errorf("Excepted %v, got %v", want, err)
+		}
+	}
+	if tab.Columns.Len() != 1 {
+		t.Errorf("Excepted 1 column, got %d", tab.Columns.Len())
+	}
+
+	if err := tab.AddColumns(&columns.Column{Name: "A", Visible: true}, &columns.Column{Name: "B", Visible: true}); err != nil {
+		t.Fatal(err)
+	}
+	if c, err := tab.Column("B"); err != nil || c.Name != "B" {
+		t.Errorf("Excepted column B, got %v, %v", c, err)
+	}
+	if _, err := tab.Column("C"); !errors.Is(err, columns.ErrorNotFound) {
+		t.Errorf("Excepted %v, got %v", columns.ErrorNotFound, err)
+	}
+
+	defer func() {
+		if r := recover(); r != columns.ErrorAlreadyExists {
+			t.Errorf("Excepted panic %v, got %v", columns.ErrorAlreadyExists, r)
+		}
+	}()
+	tab.AddColumn("ID", 10, AlignLeft)
+}
+
+func TestStyle(t *testing.T) {
+	tab := New("", BorderSimple, nil)
+	tab.AddColumn("Name", WidthAuto, AlignLeft).
+		AddColumn("Status", 6, AlignLeft)
+	tab.HeaderStyle = style.Style{Bold: true}
+	tab.Columns.FindByName("Name").Style = style.Style{FG: style.Cyan}
+	tab.RowStyle = func(n int, rec map[string]interface{}) style.Style {
+		if rec["Status"] == "FAIL" {
+			return style.Style{FG: style.Red}
+		}
+		return style.Style{}
+	}
+	tab.AppendData(map[string]interface{}{"Name": "a", "Status": "OK"})
+	tab.AppendData(map[string]interface{}{"Name": "b", "Status": "FAIL"})
+	tab.AppendData(map[string]interface{}{"Name": "\x1b[4mc\x1b[0m", "Status": style.Style{BG: style.Yellow}.Wrap("WARNING")})
+
+	plain := fmt.Sprintf("Name|Status%[1]s----+------%[1]sa   |OK    %[1]sb   |FAIL  %[1]s\x1b[4mc\x1b[0m   |WARN..%[1]s", eol.EOL)
+	if res := tab.String(); res != plain {
+		t.Errorf("Excepted \n%q, got:\n%q", plain, res)
+	}
+
+	tab.ColorMode = style.ModeAlways
+	org := fmt.Sprintf("\x1b[1mName\x1b[0m|\x1b[1mStatus\x1b[0m%[1]s----+------%[1]s"+
+		"\x1b[36ma   \x1b[0m|OK    %[1]s"+
+		"\x1b[31mb   \x1b[0m|\x1b[31mFAIL  \x1b[0m%[1]s"+
+		"\x1b[36m\x1b[4mc\x1b[0m   \x1b[0m|\x1b[43mWARN..\x1b[0m%[1]s", eol.EOL)
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+}
+
+func TestZebra(t *testing.T) {
+	n := 0
+	tab := New("", BorderThin, func() (bool, map[string]interface{}) {
+		n++
+		return n <= 3, map[string]interface{}{"N": n}
+	})
+	tab.AddColumn("N", 2, AlignRight)
+	tab.ZebraMarker = "▌"
+	tab.Zebra = style.Style{BG: style.BrightBlack}
+	org := fmt.Sprintf(" ┌──┐%[1]s │ N│%[1]s ├──┤%[1]s │ 1│%[1]s▌│ 2│%[1]s │ 3│%[1]s └──┘%[1]s", eol.EOL)
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+
+	n = 0
+	tab.ZebraMarker = ""
+	tab.VisibleHeader = false
+	tab.ColorMode = style.ModeAlways
+	org = fmt.Sprintf("┌──┐%[1]s│ 1│%[1]s│\x1b[100m 2\x1b[0m│%[1]s│ 3│%[1]s└──┘%[1]s", eol.EOL)
+	if res := tab.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+	tab2 := New("", BorderThin, nil)
+	tab2.AddColumn("Name", WidthAuto, AlignLeft).
+		AddColumn("Value", WidthAuto, AlignLeft)
+	tab2.AppendRow("first", "value")
+	tab2.AppendRow("second", "value")
+	tab2.ZebraMarker = "▌"
+	tab2.AutoSize(true, 16)
+	org = fmt.Sprintf(" ┌──────┬─────┐%[1]s │Name  │Value│%[1]s ├──────┼─────┤%[1]s │first │value│%[1]s▌│second│value│%[1]s └──────┴─────┘%[1]s", eol.EOL)
+	if res := tab2.String(); res != org {
+		t.Errorf("Excepted \n%q, got:\n%q", org, res)
+	}
+}
@@ -3,239 +3,522 @@ package fmttab
 import (
 	"bufio"
 	"bytes"
-	"fmt"
 	"io"
 	"math"
 	"strings"
-	"unicode/utf8"
 
 	"github.com/arteev/fmttab/columns"
 	"github.com/arteev/fmttab/eol"
+	"github.com/arteev/fmttab/style"
+	"github.com/arteev/fmttab/textwidth"
 )
 
-func (t *Table) writeHeader(buf *bufio.Writer) (int, error) {
-	if t.caption != "" {
-		buf.WriteString(t.caption)
-		buf.WriteString(eol.EOL)
-	}
-	buf.WriteString(Borders[t.border][BKLeftTop])
+//A boxPart the part of the box table written last, it defines the horizontal line before the next part
+type boxPart int
 
-	cntCols := t.columnsvisible.Len()
-	num := 0
-	t.columnsvisible.Visit(func(c *columns.Column) error {
-		cnw, _ := buf.WriteString(strings.Repeat(Borders[t.border][BKHorizontalBorder], c.GetWidth()))
-		if num < cntCols-1 {
-			buf.WriteString(Borders[t.border][BKTopToBottom])
-		} else {
-			buf.WriteString(Borders[t.border][BKRighttop])
-			if cnw > 0 {
-				buf.WriteString(eol.EOL)
-			}
+const (
+	boxTop boxPart = iota
+	boxHeader
+	boxRow
+	boxGroup
+	boxSubtotal
+	boxFooter
+	boxEnd
+)
+
+//A BoxRenderer draws the table with the borders, it is the default renderer of Table
+type BoxRenderer struct {
+	t     *Table
+	cw    *countWriter
+	buf   *bufio.Writer
+	w     io.Writer
+	color bool
+	cells [][]string
+	rows  int
+	last  boxPart
+	//bounds the junctions of columns where the cells of the last row end
+	bounds []bool
+	//spare the junctions of the row before the last one, reused for the next row
+	spare []bool
+	//scratch the buffer of the horizontal lines
+	scratch bytes.Buffer
+	//full the junctions of the rows without spans
+	full []bool
+	//merged the columns of the last row continuing the merged cells of the row above
+	merged []bool
+	widths []int
+	sep    bool
+	//gutter the blank place of ZebraMarker before the lines of the table
+	gutter string
+}
+
+//NewBoxRenderer creates the renderer of the table with the borders writing to w.
+//The styles are written according to ColorMode of the table
+func NewBoxRenderer(w io.Writer) *BoxRenderer {
+	cw := &countWriter{w: w}
+	return &BoxRenderer{cw: cw, buf: bufio.NewWriter(cw), w: w}
+}
+
+//Written returns the number of bytes written
+func (b *BoxRenderer) Written() int64 {
+	return b.cw.n
+}
+
+//BeginTable writes the caption of the table, the top border is written before the next part of the table
+func (b *BoxRenderer) BeginTable(t *Table) error {
+	b.t = t
+	b.rows = 0
+	b.last = boxTop
+	b.sep = false
+	b.bounds = nil
+	b.spare = nil
+	b.widths = t.columnWidths()
+	b.full = nil
+	b.merged = nil
+	if n := len(b.widths); n > 0 {
+		b.full = make([]bool, n-1)
+		for i := range b.full {
+			b.full[i] = true
 		}
-		num++
+	}
+	b.color = t.ColorMode.Enabled(b.w)
+	b.gutter = strings.Repeat(" ", textwidth.String(t.ZebraMarker))
+	if t.columnsvisible.Len() == 0 || t.caption == "" {
 		return nil
-	})
+	}
+	b.buf.WriteString(t.caption)
+	_, err := b.buf.WriteString(eol.EOL)
+	return err
+}
 
-	if t.VisibleHeader {
-		buf.WriteString(Borders[t.border][BKVerticalBorder])
-		num := 0
-		t.columnsvisible.Visit(func(c *columns.Column) error {
-			caption := fmt.Sprintf(c.GetMaskFormat(), c.Caption)
-			buf.WriteString(trimEnds(caption, c.GetWidth()))
-			bKind := BKVertical
-			if num == cntCols-1 {
-				bKind = BKVerticalBorder
-			}
-			buf.WriteString(Borders[t.border][bKind])
-			num++
-			return nil
-		})
-		buf.WriteString(eol.EOL)
-		buf.WriteString(Borders[t.border][BKLeftToRight])
-		s := t.getBorderTopButtomData(BKHorizontal, BKBottomCross, BKRightToLeft)
-		if _, err := buf.WriteString(s); err != nil {
-			return 0, err
+//joint writes the horizontal line between the last part of the table and the next one.
+//The junctions of the line depend on the cells of both parts ending at the junctions,
+//bounds are the junctions of the next part, nil for the parts spanning all columns
+func (b *BoxRenderer) joint(next boxPart, bounds []bool) error {
+	prev, up := b.last, b.bounds
+	sep := b.sep
+	b.last, b.bounds, b.sep = next, bounds, false
+	b.spare = up
+	switch {
+	case prev == boxTop && next == boxEnd:
+		//the empty table is drawn with junctions of columns
+		b.writeLine(b.gutter, b.line(BKLeftTop, BKHorizontalBorder, BKRighttop, nil, b.full, nil))
+		up = b.full
+	case prev == boxHeader && next == boxEnd:
+		//the header is always closed by the line
+		b.writeLine(b.gutter, b.line(BKLeftToRight, BKHorizontal, BKRightToLeft, up, up, nil))
+	case prev == next && (next == boxFooter || next == boxSubtotal || next == boxRow && !sep):
+		return nil
+	}
+	var line []byte
+	switch {
+	case prev == boxTop:
+		line = b.line(BKLeftTop, BKHorizontalBorder, BKRighttop, nil, bounds, nil)
+	case next == boxEnd:
+		line = b.line(BKLeftBottom, BKHorizontalBorder, BKRightBottom, up, nil, nil)
+	case prev == boxRow && next == boxRow:
+		line = b.line(BKLeftToRight, BKHorizontal, BKRightToLeft, up, bounds, b.merged)
+		if line == nil {
+			//the records are separated by the blank line if the border has no horizontal lines
+			line = []byte(eol.EOL)
 		}
+	default:
+		line = b.line(BKLeftToRight, BKHorizontal, BKRightToLeft, up, bounds, nil)
 	}
-	return buf.Buffered(), buf.Flush()
+	return b.writeLine(b.gutter, line)
 }
 
-func (t *Table) getBorderTopButtomData(hr, vbwnCol, vright BorderKind) string {
-	var result string
-	count := t.columnsvisible.Len()
-	num := 0
-	t.columnsvisible.Visit(func(c *columns.Column) error {
-		strLine := strings.Repeat(Borders[t.border][hr], c.GetWidth())
-		if num < count-1 {
-			strLine += Borders[t.border][vbwnCol]
-		} else {
-			strLine += Borders[t.border][vright]
-			if strLine != "" {
-				strLine += eol.EOL
-			}
+//line returns the horizontal line between the rows with the junctions up and down,
+//the junction is chosen by the rows having the cells ending there.
+//The line is not drawn through the columns merged with the cells above.
+//The line is valid until the next call
+func (b *BoxRenderer) line(left, hr, right BorderKind, up, down, merged []bool) []byte {
+	t := b.t
+	at := func(bounds []bool, i int) bool {
+		return i >= 0 && i < len(bounds) && bounds[i]
+	}
+	last := len(b.widths) - 1
+	result := &b.scratch
+	result.Reset()
+	if at(merged, 0) {
+		result.WriteString(t.bs.Get(BKVerticalBorder))
+	} else {
+		result.WriteString(t.bs.Get(left))
+	}
+	for i, w := range b.widths {
+		piece := t.bs.Get(hr)
+		if at(merged, i) {
+			piece = " "
 		}
-		result += strLine
-		num++
+		for ; w > 0; w-- {
+			result.WriteString(piece)
+		}
+		if i == last {
+			break
+		}
+		u, d := at(up, i), at(down, i)
+		ml, mr := at(merged, i), at(merged, i+1)
+		switch {
+		case ml && mr:
+			result.WriteString(t.bs.Get(BKVertical))
+		case ml:
+			result.WriteString(t.bs.Get(BKLeftToRight))
+		case mr:
+			result.WriteString(t.bs.Get(BKRightToLeft))
+		case u && d && left == BKLeftToRight:
+			result.WriteString(t.bs.Get(BKBottomCross))
+		case d:
+			result.WriteString(t.bs.Get(BKTopToBottom))
+		case u:
+			result.WriteString(t.bs.Get(BKBottomToTop))
+		default:
+			result.WriteString(t.span(hr))
+		}
+	}
+	if at(merged, last) {
+		result.WriteString(t.bs.Get(BKVerticalBorder))
+	} else {
+		result.WriteString(t.bs.Get(right))
+	}
+	if result.Len() == 0 {
 		return nil
-	})
-	return result
+	}
+	result.WriteString(eol.EOL)
+	return result.Bytes()
 }
 
-func (t *Table) writeBottomBorder(buf *bufio.Writer) (int, error) {
-	s := Borders[t.border][BKLeftBottom] + t.getBorderTopButtomData(BKHorizontalBorder, BKBottomToTop, BKRightBottom)
-	if _, err := buf.WriteString(s); err != nil {
-		return 0, err
+//writeLine writes the line of the table after the gutter, empty lines are skipped
+func (b *BoxRenderer) writeLine(gutter string, line []byte) error {
+	if len(line) == 0 {
+		return nil
 	}
-	return buf.Buffered(), buf.Flush()
+	b.buf.WriteString(gutter)
+	_, err := b.buf.Write(line)
+	return err
 }
 
-func (t *Table) writeRecord(data map[string]interface{}, buf *bufio.Writer) (int, error) {
-	var cntwrite int
+//Header writes the captions of columns or the captions of the groups of columns
+func (b *BoxRenderer) Header(cells []Cell) error {
+	t := b.t
+	b.joint(boxHeader, b.boundaries(cells))
+	var line bytes.Buffer
+	vw := textwidth.String(t.bs.Get(BKVertical))
+	line.WriteString(t.bs.Get(BKVerticalBorder))
+	pos := 0
+	for num, cell := range cells {
+		c := cell.Column
+		w := spanWidth(b.widths, pos, cell.Span, vw)
+		line.WriteString(b.paint(cell.Style, c.PadCaptionWidth(trimWidth(c, cell.Text, w), w)))
+		bKind := BKVertical
+		if num == len(cells)-1 {
+			bKind = BKVerticalBorder
+		}
+		line.WriteString(t.bs.Get(bKind))
+		pos += cellSpan(cell)
+	}
+	line.WriteString(eol.EOL)
+	return b.writeLine(b.gutter, line.Bytes())
+}
 
-	cntCols := t.columnsvisible.Len()
-	if n, err := buf.WriteString(Borders[t.border][BKVerticalBorder]); err == nil {
-		cntwrite += n
-	} else {
-		return -1, err
+//Row writes the record, the cells are split into lines according to wrap modes of columns.
+//The odd records are marked by ZebraMarker of the table
+func (b *BoxRenderer) Row(cells []Cell) error {
+	b.merged = mergedColumns(b.merged, cells, len(b.widths))
+	b.joint(boxRow, b.boundaries(cells))
+	gutter := b.gutter
+	if b.rows%2 == 1 && b.t.ZebraMarker != "" {
+		gutter = b.t.ZebraMarker
 	}
+	b.rows++
+	return b.writeRecord(cells, gutter)
+}
 
-	num := 0
-	err := t.columnsvisible.Visit(func(c *columns.Column) error {
-		val, mok := data[c.Name]
-		if !mok || val == nil {
-			val = ""
-		}
+//paint applies the style to the text if the styles are enabled
+func (b *BoxRenderer) paint(s style.Style, text string) string {
+	if !b.color {
+		return text
+	}
+	return s.Render(text)
+}
 
-		mask, ok := t.masks[c.Name]
-		if !ok {
-			mask = c.GetMaskFormat()
-			t.masks[c.Name] = mask
-		}
+//Separator marks that the next row is separated by the horizontal line
+func (b *BoxRenderer) Separator() error {
+	b.sep = true
+	return nil
+}
 
-		caption := fmt.Sprintf(mask, val)
-		n, err := buf.WriteString(trimEnds(caption, c.GetWidth()))
-		if err != nil {
-			return err
+//Group writes the header of the group of records spanning all columns
+func (b *BoxRenderer) Group(key Cell) error {
+	t := b.t
+	b.joint(boxGroup, nil)
+	width := spanWidth(b.widths, 0, len(b.widths), textwidth.String(t.bs.Get(BKVertical)))
+	text := strings.Join(textwidth.Lines(key.Text), " ")
+	text = textwidth.PadRight(trimEnds(text, width), width)
+	return b.writeLine(b.gutter, []byte(t.bs.Get(BKVerticalBorder)+b.paint(key.Style, text)+t.bs.Get(BKVerticalBorder)+eol.EOL))
+}
+
+//Subtotal writes the row of the subtotals of the group separated from the records by the horizontal line
+func (b *BoxRenderer) Subtotal(cells []Cell) error {
+	b.joint(boxSubtotal, b.boundaries(cells))
+	return b.writeRecord(cells, b.gutter)
+}
+
+//Footer writes the row of the footer, the first row is separated from the data by the horizontal line
+func (b *BoxRenderer) Footer(cells []Cell) error {
+	b.joint(boxFooter, b.boundaries(cells))
+	return b.writeRecord(cells, b.gutter)
+}
+
+//EndTable writes the bottom border of the table and flushes the output
+func (b *BoxRenderer) EndTable() error {
+	if b.t.columnsvisible.Len() > 0 {
+		b.joint(boxEnd, nil)
+	}
+	return b.buf.Flush()
+}
+
+//span returns the line continuing through the junction of columns
+func (t *Table) span(hr BorderKind) string {
+	return strings.Repeat(t.bs.Get(hr), textwidth.String(t.bs.Get(BKVertical)))
+}
+
+func (b *BoxRenderer) writeRecord(row []Cell, gutter string) error {
+	t, buf := b.t, b.buf
+	vw := textwidth.String(t.bs.Get(BKVertical))
+	cells := b.cells[:0]
+	height := 1
+	pos := 0
+	for _, cell := range row {
+		var lines []string
+		if n := len(cells); n < cap(cells) {
+			//the lines of the cell of the previous record are reused
+			lines = cells[:n+1][n][:0]
+		}
+		lines = appendCell(lines, cell.Column, cell.Text, spanWidth(b.widths, pos, cell.Span, vw))
+		if len(lines) > height {
+			height = len(lines)
 		}
-		cntwrite += n
+		cells = append(cells, lines)
+		pos += cellSpan(cell)
+	}
+	b.cells = cells
 
-		if num < cntCols-1 {
-			n, err = buf.WriteString(Borders[t.border][BKVertical])
-		} else {
-			n, err = buf.WriteString(Borders[t.border][BKVerticalBorder])
+	for line := 0; line < height; line++ {
+		buf.WriteString(gutter)
+		buf.WriteString(t.bs.Get(BKVerticalBorder))
+		pos := 0
+		for num, cell := range row {
+			var text string
+			if line < len(cells[num]) {
+				text = cells[num][line]
+			}
+			b.pad(cell, text, spanWidth(b.widths, pos, cell.Span, vw))
+			if num < len(row)-1 {
+				buf.WriteString(t.bs.Get(BKVertical))
+			} else {
+				buf.WriteString(t.bs.Get(BKVerticalBorder))
+			}
+			pos += cellSpan(cell)
 		}
-		if err != nil {
+		if _, err := buf.WriteString(eol.EOL); err != nil {
 			return err
 		}
-		cntwrite += n
+	}
+	return nil
+}
 
-		num++
-		return nil
-	})
+//boundaries returns the junctions of the cells, the junctions of the row before the last one are reused
+func (b *BoxRenderer) boundaries(cells []Cell) []bool {
+	return boundaries(b.spare, cells, len(b.widths))
+}
 
-	if err != nil {
-		return -1, err
+//pad writes the text of the cell aligned in the width.
+//The text without style aligned to the left or to the right is padded in place
+func (b *BoxRenderer) pad(cell Cell, text string, width int) {
+	c := cell.Column
+	if b.color && !cell.Style.IsZero() || c.Aling != columns.AlignDefault && c.Aling != columns.AlignLeft && c.Aling != columns.AlignRight {
+		b.buf.WriteString(b.paint(cell.Style, c.PadWidth(text, width)))
+		return
 	}
-	n, err := buf.WriteString(eol.EOL)
-	if err != nil {
-		return -1, err
+	n := width - textwidth.String(text)
+	if c.Aling == columns.AlignRight {
+		b.spaces(n)
+	}
+	b.buf.WriteString(text)
+	if c.Aling != columns.AlignRight {
+		b.spaces(n)
 	}
-	cntwrite += n
+}
 
-	return cntwrite, nil
+//spaces writes n spaces
+func (b *BoxRenderer) spaces(n int) {
+	for ; n > 0; n-- {
+		b.buf.WriteByte(' ')
+	}
 }
 
-func (t *Table) getRecordHorBorder() string {
-	var result string
-	num := 0
-	count := t.columnsvisible.Len()
-	result += Borders[t.border][BKLeftToRight]
-	t.columnsvisible.Visit(func(c *columns.Column) error {
-		strLine := strings.Repeat(Borders[t.border][BKHorizontal], c.GetWidth())
-		if num < count-1 {
-			result += strLine + Borders[t.border][BKBottomCross]
-		} else {
-			result += strLine + Borders[t.border][BKRightToLeft]
+//mergedColumns returns the columns of the cells merged with the cells above, the slice is reused
+func mergedColumns(merged []bool, cells []Cell, count int) []bool {
+	if cap(merged) < count {
+		merged = make([]bool, count)
+	}
+	merged = merged[:count]
+	pos := 0
+	for _, cell := range cells {
+		for i := pos; i < pos+cellSpan(cell) && i < count; i++ {
+			merged[i] = cell.Merged
 		}
-		num++
-		return nil
-	})
-	return result + eol.EOL
+		pos += cellSpan(cell)
+	}
+	return merged
 }
 
-func (t *Table) writeData(buf *bufio.Writer) (int, error) {
-	firstrow := true
-	var recordSeparator string
-	if t.dataget != nil {
-		for {
-			ok, data := t.dataget()
-			if !ok {
-				break
-			}
-			if (!firstrow) && t.CloseEachColumn {
-				if recordSeparator == "" {
-					recordSeparator = t.getRecordHorBorder()
-				}
-				if _, err := buf.WriteString(recordSeparator); err != nil {
-					return -1, err
-				}
-			}
-			firstrow = false
-			if _, err := t.writeRecord(data, buf); err != nil {
-				return -1, err
-			}
+//cellSpan returns the count of columns covered by the cell
+func cellSpan(cell Cell) int {
+	if cell.Span < 1 {
+		return 1
+	}
+	return cell.Span
+}
+
+//measure returns the display width of the text of the cell
+func measure(c *columns.Column, val string) int {
+	if c.Wrap == columns.WrapNone {
+		return textwidth.String(strings.Join(textwidth.Lines(val), " "))
+	}
+	max := 0
+	for _, line := range textwidth.Lines(val) {
+		if w := textwidth.String(line); w > max {
+			max = w
 		}
-		return buf.Buffered(), buf.Flush()
 	}
+	return max
+}
+
+//A columnFit the measuring of the values of the auto sized or decimal aligned column
+type columnFit struct {
+	c        *columns.Column
+	autosize bool
+	decimal  bool
+	intlen   int
+}
 
-	if t.CountData() == 0 {
-		return buf.Buffered(), buf.Flush()
+//fit measures the text of the value of the column
+func (f *columnFit) fit(val string) {
+	c := f.c
+	curlen := measure(c, val)
+	if curlen > c.MaxLen && f.autosize {
+		c.MaxLen = curlen
 	}
-	for ii, data := range t.Data {
-		if _, err := t.writeRecord(data, buf); err != nil {
-			return -1, err
+	if f.decimal {
+		frac, ok := c.Fraction(val)
+		if frac > c.DecimalLen {
+			c.DecimalLen = frac
 		}
-		if t.CloseEachColumn {
-			if ii < len(t.Data)-1 {
-				if recordSeparator == "" {
-					recordSeparator = t.getRecordHorBorder()
-				}
-				if _, err := buf.WriteString(recordSeparator); err != nil {
-					return -1, err
-				}
-			}
+		if ok {
+			curlen -= frac + 1
+		}
+		unit := c.Unit(val)
+		if unit > c.UnitLen {
+			c.UnitLen = unit
+		}
+		curlen -= unit
+		if curlen > f.intlen {
+			f.intlen = curlen
 		}
 	}
+}
 
-	return buf.Buffered(), buf.Flush()
+//A spanFit the width of the text of the cell spanning count columns from the column with index pos
+type spanFit struct {
+	pos, count, width int
 }
 
-func (t *Table) adjustmentWidth() error {
+func (t *Table) adjustmentWidth(footer [][]footerCell) error {
 	resized := false
-	t.Columns.Visit(func(c *columns.Column) error {
-		if t.autoSize > 0 || c.IsAutoSize() {
-			c.MaxLen = len(c.Caption)
+	streaming := t.streaming()
+	fits := make([]*columnFit, t.columnsvisible.Len())
+	measured := false
+	num := 0
+	t.columnsvisible.Visit(func(c *columns.Column) error {
+		index := num
+		num++
+		autosize := t.autoSize > 0 || c.IsAutoSize()
+		decimal := c.Aling == columns.AlignDecimal
+		if !autosize && !decimal {
+			return nil
+		}
+		if autosize {
+			c.MaxLen = textwidth.String(t.headerText(c))
 			resized = true
-
-			//loop on data
-			for _, data := range t.Data {
-				curval := fmt.Sprintf("%v", data[c.Name])
-				curlen := utf8.RuneCountInString(curval)
-				if curlen > c.MaxLen {
-					c.MaxLen = curlen
-				}
-			}
-
 		}
-
+		c.DecimalLen = 0
+		c.UnitLen = 0
+		fits[index] = &columnFit{c: c, autosize: autosize, decimal: decimal}
+		measured = true
 		return nil
 	})
+	//loop on data, the cells spanning several columns are fitted after the columns
+	var spans []spanFit
+	if measured {
+		t.visitFiltered(func(r record) error {
+			num, covered := 0, 0
+			return t.columnsvisible.Visit(func(c *columns.Column) error {
+				index := num
+				num++
+				if covered > 0 {
+					covered--
+					return nil
+				}
+				val, _, span := r.cell(index, c)
+				if span > len(fits)-index {
+					span = len(fits) - index
+				}
+				if span > 1 {
+					covered = span - 1
+					spans = append(spans, spanFit{pos: index, count: span, width: measure(c, c.Format(val))})
+				} else if fits[index] != nil {
+					fits[index].fit(c.Format(val))
+				}
+				return nil
+			})
+		})
+	}
+	for index, f := range fits {
+		if f == nil {
+			continue
+		}
+		//the aggregates of streamed records are unknown yet
+		for _, row := range footer {
+			if row[index].agg == nil || !streaming {
+				f.fit(f.c.Format(row[index].result()))
+			}
+		}
+		if f.decimal && f.autosize {
+			c := f.c
+			numlen := f.intlen + c.UnitLen
+			if c.DecimalLen > 0 {
+				numlen += c.DecimalLen + 1
+			}
+			if numlen > c.MaxLen {
+				c.MaxLen = numlen
+			}
+		}
+	}
+	if len(spans) > 0 {
+		widths := t.columnWidths()
+		for _, s := range spans {
+			t.fitSpan(widths, s.pos, s.count, s.width)
+		}
+	}
 	if !resized {
 		return nil
 	}
 	//adjustment of table
 	if t.autoSize > 0 {
-		termwidth := t.autoSize - utf8.RuneCountInString(Borders[t.border][BKVertical])*t.columnsvisible.Len() - utf8.RuneCountInString(Borders[t.border][BKVerticalBorder])*2
+		termwidth := t.autoSize - textwidth.String(t.bs.Get(BKVertical))*t.columnsvisible.Len() - textwidth.String(t.bs.Get(BKVerticalBorder))*2
+		//the lines of the table are indented by the gutter of ZebraMarker
+		termwidth -= textwidth.String(t.ZebraMarker)
 		nowwidths := make(map[string]int, t.columnsvisible.Len())
 		allcolswidth := 0
 
@@ -279,32 +562,11 @@ func (t *Table) adjustmentWidth() error {
 // int, but it is int64 to match the io.WriterTo interface. Any error
 // encountered during the write is also returned.
 func (t *Table) WriteTo(w io.Writer) (int64, error) {
-	t.masks = make(map[string]string)
-	t.columnsvisible = t.Columns.ColumnsVisible()
-	buf := bufio.NewWriter(w)
-	if t.columnsvisible.Len() == 0 {
-		return 0, nil
-	}
-	if err := t.adjustmentWidth(); err != nil {
-		return 0, err
-	}
-	var cntwrite int64
-	if n, err := t.writeHeader(buf); err == nil {
-		cntwrite += int64(n)
-	} else {
+	box := NewBoxRenderer(w)
+	if err := t.Render(box); err != nil {
 		return -1, err
 	}
-	if n, err := t.writeData(buf); err == nil {
-		cntwrite += int64(n)
-	} else {
-		return -1, err
-	}
-	if n, err := t.writeBottomBorder(buf); err == nil {
-		cntwrite += int64(n)
-	} else {
-		return -1, err
-	}
-	return cntwrite, nil
+	return box.Written(), nil
 }
 
 // String returns the contents of the table with borders
@@ -316,3 +578,15 @@ func (t *Table) String() string {
 	}
 	return buf.String()
 }
+
+//A countWriter counts the bytes written to the underlying writer
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
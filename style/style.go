@@ -0,0 +1,179 @@
+//Package style describes ANSI styles of text in a terminal.
+package style
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+//Reset the escape sequence resetting all attributes of text
+const Reset = "\x1b[0m"
+
+//A Color the color of text or background of the 16 colors palette
+type Color int
+
+//Colors
+const (
+	//ColorDefault the color of the terminal
+	ColorDefault Color = iota
+	Black
+	Red
+	Green
+	Yellow
+	Blue
+	Magenta
+	Cyan
+	White
+	BrightBlack
+	BrightRed
+	BrightGreen
+	BrightYellow
+	BrightBlue
+	BrightMagenta
+	BrightCyan
+	BrightWhite
+)
+
+//code returns the SGR code of the color, base is 30 for text and 40 for background
+func (c Color) code(base int) string {
+	switch {
+	case c >= Black && c <= White:
+		return strconv.Itoa(base + int(c-Black))
+	case c >= BrightBlack && c <= BrightWhite:
+		return strconv.Itoa(base + 60 + int(c-BrightBlack))
+	}
+	return ""
+}
+
+//A Style the attributes of text
+type Style struct {
+	//FG the color of text
+	FG Color
+	//BG the color of background
+	BG        Color
+	Bold      bool
+	Dim       bool
+	Underline bool
+	//NoBold, NoDim and NoUnderline turn off the attributes of the styles merged under the style
+	NoBold      bool
+	NoDim       bool
+	NoUnderline bool
+}
+
+//IsZero reports whether the style has no attributes
+func (s Style) IsZero() bool {
+	return s == Style{}
+}
+
+//Merge returns the style with the attributes of o over the attributes of s.
+//The attributes turned off by o are turned off in the result
+func (s Style) Merge(o Style) Style {
+	if o.FG != ColorDefault {
+		s.FG = o.FG
+	}
+	if o.BG != ColorDefault {
+		s.BG = o.BG
+	}
+	s.Bold, s.NoBold = merge(s.Bold, s.NoBold, o.Bold, o.NoBold)
+	s.Dim, s.NoDim = merge(s.Dim, s.NoDim, o.Dim, o.NoDim)
+	s.Underline, s.NoUnderline = merge(s.Underline, s.NoUnderline, o.Underline, o.NoUnderline)
+	return s
+}
+
+//merge returns the state of the attribute set by on and off over the state of the attribute below,
+//off wins over on
+func merge(below, belowOff, on, off bool) (bool, bool) {
+	switch {
+	case off:
+		return false, true
+	case on:
+		return true, false
+	}
+	return below, belowOff
+}
+
+//Sequence returns the escape sequence setting the attributes of the style
+func (s Style) Sequence() string {
+	var codes []string
+	if s.Bold {
+		codes = append(codes, "1")
+	}
+	if s.Dim {
+		codes = append(codes, "2")
+	}
+	if s.Underline {
+		codes = append(codes, "4")
+	}
+	if code := s.FG.code(30); code != "" {
+		codes = append(codes, code)
+	}
+	if code := s.BG.code(40); code != "" {
+		codes = append(codes, code)
+	}
+	if len(codes) == 0 {
+		return ""
+	}
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
+
+//Render returns text with the attributes of the style
+func (s Style) Render(text string) string {
+	seq := s.Sequence()
+	if seq == "" || text == "" {
+		return text
+	}
+	return seq + text + Reset
+}
+
+//Wrap returns the value of a cell with the style
+func (s Style) Wrap(v interface{}) Value {
+	return Value{Value: v, Style: s}
+}
+
+//A Value the value of a cell with its own style
+type Value struct {
+	Value interface{}
+	Style Style
+}
+
+//A Mode defines when the styles are written
+type Mode int
+
+//Modes
+const (
+	//ModeAuto the styles are written to terminals if NO_COLOR is not set
+	ModeAuto Mode = iota
+	//ModeAlways the styles are always written
+	ModeAlways
+	//ModeNever the styles are never written
+	ModeNever
+)
+
+//Enabled reports whether the styles are written to w in the mode
+func (m Mode) Enabled(w io.Writer) bool {
+	switch m {
+	case ModeAlways:
+		return true
+	case ModeNever:
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return IsTerminal(w)
+}
+
+//IsTerminal reports whether w is a terminal
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
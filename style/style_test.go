@@ -0,0 +1,71 @@
+package style
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestSequence(t *testing.T) {
+	test := map[Style]string{
+		{}:                                 "",
+		{FG: Red}:                          "\x1b[31m",
+		{FG: BrightWhite, BG: Blue}:        "\x1b[97;44m",
+		{Bold: true, Underline: true}:      "\x1b[1;4m",
+		{Dim: true, BG: BrightBlack}:       "\x1b[2;100m",
+		{Bold: true, FG: Black, BG: White}: "\x1b[1;30;47m",
+	}
+	for s, want := range test {
+		if got := s.Sequence(); got != want {
+			t.Errorf("Sequence(%+v): expected %q, got %q", s, want, got)
+		}
+	}
+	if got, want := (Style{FG: Green}).Render("ok"), "\x1b[32mok\x1b[0m"; got != want {
+		t.Errorf("Render: expected %q, got %q", want, got)
+	}
+	if got := (Style{}).Render("ok"); got != "ok" {
+		t.Errorf("Render: expected %q, got %q", "ok", got)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	s := Style{FG: Red, Bold: true}.Merge(Style{FG: Yellow, BG: Blue, Underline: true})
+	if want := (Style{FG: Yellow, BG: Blue, Bold: true, Underline: true}); s != want {
+		t.Errorf("Merge: expected %+v, got %+v", want, s)
+	}
+	if s := (Style{FG: Red}).Merge(Style{}); s != (Style{FG: Red}) {
+		t.Errorf("Merge: expected red, got %+v", s)
+	}
+	s = Style{Bold: true, Dim: true}.Merge(Style{NoBold: true}).Merge(Style{FG: Red})
+	if want := (Style{FG: Red, Dim: true, NoBold: true}); s != want {
+		t.Errorf("Merge: expected %+v, got %+v", want, s)
+	}
+	if seq := s.Sequence(); seq != "\x1b[2;31m" {
+		t.Errorf("Sequence: expected %q, got %q", "\x1b[2;31m", seq)
+	}
+	if s = s.Merge(Style{Bold: true}); !s.Bold || s.NoBold {
+		t.Errorf("Merge: expected bold, got %+v", s)
+	}
+}
+
+func TestMode(t *testing.T) {
+	var buf bytes.Buffer
+	if !ModeAlways.Enabled(&buf) || ModeNever.Enabled(os.Stdout) || ModeAuto.Enabled(&buf) {
+		t.Error("Unexpected mode")
+	}
+	old, ok := os.LookupEnv("NO_COLOR")
+	os.Setenv("NO_COLOR", "1")
+	defer func() {
+		if ok {
+			os.Setenv("NO_COLOR", old)
+		} else {
+			os.Unsetenv("NO_COLOR")
+		}
+	}()
+	if ModeAuto.Enabled(os.Stdout) {
+		t.Error("Expected disabled styles with NO_COLOR")
+	}
+	if !ModeAlways.Enabled(os.Stdout) {
+		t.Error("Expected enabled styles with ModeAlways")
+	}
+}
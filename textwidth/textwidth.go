@@ -0,0 +1,346 @@
+//Package textwidth measures the display width of text in a monospaced terminal.
+//It takes into account East Asian wide characters, combining marks,
+//zero-width joiners, grapheme clusters and ANSI escape sequences.
+package textwidth
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	esc      = '\x1b'
+	bel      = '\a'
+	zwj      = '\u200d'
+	vs16     = '\ufe0f'
+	riFirst  = 0x1F1E6
+	riLast   = 0x1F1FF
+	modFirst = 0x1F3FB
+	modLast  = 0x1F3FF
+	tagFirst = 0xE0020
+	tagLast  = 0xE007F
+)
+
+//Rune returns the number of cells occupied by r
+func Rune(r rune) int {
+	switch {
+	case r == 0:
+		return 0
+	case r < 0x20 || (r >= 0x7f && r < 0xa0):
+		return 0
+	case r < 0x300:
+		return 1
+	case isZero(r):
+		return 0
+	case inTable(r, wide):
+		return 2
+	}
+	return 1
+}
+
+func isZero(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) || inTable(r, zero)
+}
+
+func isRegional(r rune) bool {
+	return r >= riFirst && r <= riLast
+}
+
+//isExtend reports whether r continues the current grapheme cluster
+func isExtend(r rune) bool {
+	return r == zwj ||
+		(r >= modFirst && r <= modLast) ||
+		(r >= tagFirst && r <= tagLast) ||
+		unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc)
+}
+
+//escapeLen returns the length of the ANSI escape sequence at the beginning of s
+//or 0 if s does not begin with a complete escape sequence
+func escapeLen(s string) int {
+	if len(s) < 2 || s[0] != esc {
+		return 0
+	}
+	switch s[1] {
+	case '[':
+		//CSI: parameters and intermediates terminated by a final byte
+		for i := 2; i < len(s); i++ {
+			switch c := s[i]; {
+			case c >= 0x40 && c <= 0x7e:
+				return i + 1
+			case c < 0x20 || c > 0x3f:
+				return 0
+			}
+		}
+	case ']':
+		//OSC: terminated by BEL or ESC \
+		for i := 2; i < len(s); i++ {
+			switch s[i] {
+			case bel:
+				return i + 1
+			case esc:
+				if i+1 < len(s) && s[i+1] == '\\' {
+					return i + 2
+				}
+				return 0
+			}
+		}
+	default:
+		if s[1] >= 0x40 && s[1] <= 0x5f {
+			return 2
+		}
+	}
+	return 0
+}
+
+//Escapes returns the ANSI escape sequences of s joined together
+func Escapes(s string) string {
+	var res []string
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			res = append(res, s[i:i+n])
+			i += n
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+	return strings.Join(res, "")
+}
+
+//Strip removes the ANSI escape sequences from s
+func Strip(s string) string {
+	if strings.IndexByte(s, esc) < 0 {
+		return s
+	}
+	var res []byte
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		res = append(res, s[i])
+		i++
+	}
+	return string(res)
+}
+
+//Grapheme returns the first grapheme cluster of s and its width.
+//An ANSI escape sequence is returned as a cluster of zero width
+func Grapheme(s string) (cluster string, width int) {
+	if s == "" {
+		return "", 0
+	}
+	if n := escapeLen(s); n > 0 {
+		return s[:n], 0
+	}
+	first, size := utf8.DecodeRuneInString(s)
+	if first == '\r' && len(s) > 1 && s[1] == '\n' {
+		return s[:2], 0
+	}
+	width = Rune(first)
+	end := size
+	prev := first
+	regional := isRegional(first)
+	for end < len(s) {
+		r, n := utf8.DecodeRuneInString(s[end:])
+		switch {
+		case prev == zwj && r != zwj:
+		case r == vs16:
+			if width == 1 {
+				width = 2
+			}
+		case isExtend(r):
+		case regional && isRegional(r):
+			regional = false
+			width = 2
+		default:
+			return s[:end], width
+		}
+		prev = r
+		end += n
+	}
+	return s[:end], width
+}
+
+//Graphemes splits s into grapheme clusters
+func Graphemes(s string) []string {
+	var res []string
+	for s != "" {
+		g, _ := Grapheme(s)
+		res = append(res, g)
+		s = s[len(g):]
+	}
+	return res
+}
+
+//String returns the number of cells occupied by s
+func String(s string) int {
+	w := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c >= 0x20 && c < 0x7f {
+			//fast path for ASCII not followed by a combining mark
+			if i+1 == len(s) || s[i+1] < 0x80 {
+				w++
+				i++
+				continue
+			}
+		}
+		g, gw := Grapheme(s[i:])
+		w += gw
+		i += len(g)
+	}
+	return w
+}
+
+//Truncate cuts s so that it fits into width cells together with tail.
+//The string is never cut inside a grapheme cluster or an escape sequence,
+//the escape sequences of the cut part are kept after tail.
+//If s fits into width, it is returned unchanged.
+func Truncate(s string, width int, tail string) string {
+	if width < 0 {
+		width = 0
+	}
+	if String(s) <= width {
+		return s
+	}
+	tw := String(tail)
+	if tw > width {
+		return Truncate(tail, width, "")
+	}
+	h := head(s, width-tw)
+	return h + tail + Escapes(s[len(h):])
+}
+
+//TruncateStart cuts the beginning of s so that it fits into width cells
+//together with lead placed before the rest of the string
+func TruncateStart(s string, width int, lead string) string {
+	if String(s) <= width {
+		return s
+	}
+	lw := String(lead)
+	if lw > width {
+		return Truncate(lead, width, "")
+	}
+	t := tail(s, width-lw)
+	return Escapes(s[:len(s)-len(t)]) + lead + t
+}
+
+//TruncateMiddle cuts the middle of s so that it fits into width cells
+//together with mid placed between the beginning and the end of the string
+func TruncateMiddle(s string, width int, mid string) string {
+	if String(s) <= width {
+		return s
+	}
+	mw := String(mid)
+	if mw > width {
+		return Truncate(mid, width, "")
+	}
+	left := head(s, (width-mw+1)/2)
+	rest := s[len(left):]
+	right := tail(rest, width-mw-String(left))
+	return left + mid + Escapes(rest[:len(rest)-len(right)]) + right
+}
+
+//head returns the longest prefix of s not exceeding width cells
+func head(s string, width int) string {
+	w, end := 0, 0
+	for end < len(s) {
+		g, gw := Grapheme(s[end:])
+		if w+gw > width {
+			break
+		}
+		w += gw
+		end += len(g)
+	}
+	return s[:end]
+}
+
+//tail returns the longest suffix of s not exceeding width cells
+func tail(s string, width int) string {
+	clusters := Graphemes(s)
+	w, start := 0, len(s)
+	for i := len(clusters) - 1; i >= 0; i-- {
+		gw := String(clusters[i])
+		if w+gw > width {
+			break
+		}
+		w += gw
+		start -= len(clusters[i])
+	}
+	return s[start:]
+}
+
+//PadRight appends spaces to s up to width cells
+func PadRight(s string, width int) string {
+	if n := width - String(s); n > 0 {
+		return s + strings.Repeat(" ", n)
+	}
+	return s
+}
+
+//PadLeft prepends spaces to s up to width cells
+func PadLeft(s string, width int) string {
+	if n := width - String(s); n > 0 {
+		return strings.Repeat(" ", n) + s
+	}
+	return s
+}
+
+//Lines splits s into lines by line breaks
+func Lines(s string) []string {
+	return strings.Split(strings.Replace(s, "\r\n", "\n", -1), "\n")
+}
+
+//WrapHard splits s into lines of at most width cells.
+//Line breaks in s are kept, lines are cut between grapheme clusters
+func WrapHard(s string, width int) []string {
+	var res []string
+	for _, line := range Lines(s) {
+		res = append(res, chunks(line, width)...)
+	}
+	return res
+}
+
+//WrapWords splits s into lines of at most width cells breaking lines between words.
+//Line breaks in s are kept, words longer than width are cut
+func WrapWords(s string, width int) []string {
+	var res []string
+	for _, line := range Lines(s) {
+		cur, curw := "", 0
+		for _, word := range strings.Fields(line) {
+			ww := String(word)
+			switch {
+			case cur != "" && curw+1+ww <= width:
+				cur += " " + word
+				curw += 1 + ww
+				continue
+			case cur != "":
+				res = append(res, cur)
+			}
+			parts := chunks(word, width)
+			res = append(res, parts[:len(parts)-1]...)
+			cur = parts[len(parts)-1]
+			curw = String(cur)
+		}
+		res = append(res, cur)
+	}
+	return res
+}
+
+//chunks cuts s into pieces of at most width cells, each piece contains at least one grapheme cluster
+func chunks(s string, width int) []string {
+	if s == "" || width <= 0 {
+		return []string{s}
+	}
+	var res []string
+	for s != "" {
+		part := head(s, width)
+		if part == "" {
+			part, _ = Grapheme(s)
+		}
+		res = append(res, part)
+		s = s[len(part):]
+	}
+	return res
+}
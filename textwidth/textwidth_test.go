@@ -0,0 +1,185 @@
+package textwidth
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRune(t *testing.T) {
+	test := map[rune]int{
+		'a':          1,
+		'Ж':          1,
+		'日':          2,
+		'한':          2,
+		'Ａ':          2,
+		'\u0301':     0,
+		'\u200d':     0,
+		'\u200b':     0,
+		'\t':         0,
+		'\U0001f600': 2,
+	}
+	for r, want := range test {
+		if got := Rune(r); got != want {
+			t.Errorf("Rune(%q): expected %d, got %d", r, want, got)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	test := map[string]int{
+		"":        0,
+		"testing": 7,
+		"Русский": 7,
+		"日本語":     6,
+		"e\u0301": 1,
+		"\U0001f468\u200d\U0001f469\u200d\U0001f467": 2,
+		"\U0001f44d\U0001f3fd":                       2,
+		"\U0001f1f7\U0001f1fa":                       2,
+		"\u2764\ufe0f":                               2,
+		"abc日本":                                      7,
+		"Cafe\u0301 au lait":                         12,
+		"\u0915\u094d\u0937\u093f":                   2,
+	}
+	for s, want := range test {
+		if got := String(s); got != want {
+			t.Errorf("String(%q): expected %d, got %d", s, want, got)
+		}
+	}
+}
+
+func TestGraphemes(t *testing.T) {
+	test := map[string][]string{
+		"abc":      {"a", "b", "c"},
+		"e\u0301x": {"e\u0301", "x"},
+		"\U0001f468\u200d\U0001f469\u200d\U0001f467!": {"\U0001f468\u200d\U0001f469\u200d\U0001f467", "!"},
+		"\U0001f1f7\U0001f1fa\U0001f1e9\U0001f1ea":    {"\U0001f1f7\U0001f1fa", "\U0001f1e9\U0001f1ea"},
+		"a\r\nb": {"a", "\r\n", "b"},
+	}
+	for s, want := range test {
+		if got := Graphemes(s); !reflect.DeepEqual(got, want) {
+			t.Errorf("Graphemes(%q): expected %q, got %q", s, want, got)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	test := []struct {
+		val   string
+		width int
+		tail  string
+		want  string
+	}{
+		{"testing", 7, "..", "testing"},
+		{"testing", 6, "..", "test.."},
+		{"testing", 2, "..", ".."},
+		{"testing", 1, "..", "."},
+		{"Русский", 5, "..", "Рус.."},
+		{"日本語です", 6, "..", "日本.."},
+		{"日本語です", 5, "..", "日.."},
+		{"e\u0301e\u0301e\u0301", 2, "", "e\u0301e\u0301"},
+		{"日本語", 3, "\u2026", "\u65e5\u2026"},
+	}
+	for _, tt := range test {
+		if got := Truncate(tt.val, tt.width, tt.tail); got != tt.want {
+			t.Errorf("Truncate(%q,%d,%q): expected %q, got %q", tt.val, tt.width, tt.tail, tt.want, got)
+		}
+	}
+}
+
+func TestTruncateStartMiddle(t *testing.T) {
+	test := []struct {
+		val    string
+		width  int
+		mode   func(string, int, string) string
+		marker string
+		want   string
+	}{
+		{"/usr/local/src/file.go", 22, TruncateStart, "…", "/usr/local/src/file.go"},
+		{"/usr/local/src/file.go", 10, TruncateStart, "…", "…c/file.go"},
+		{"/usr/local/src/file.go", 14, TruncateMiddle, "/…/", "/usr/l/…/le.go"},
+		{"/usr/local/src/file.go", 11, TruncateMiddle, "…", "/usr/…le.go"},
+		{"Русский язык", 7, TruncateMiddle, "..", "Рус..ык"},
+		{"日本語です", 5, TruncateStart, "..", "..す"},
+		{"日本語です", 6, TruncateMiddle, "…", "日…す"},
+		{"testing", 1, TruncateStart, "..", "."},
+	}
+	for _, tt := range test {
+		if got := tt.mode(tt.val, tt.width, tt.marker); got != tt.want {
+			t.Errorf("(%q,%d,%q): expected %q, got %q", tt.val, tt.width, tt.marker, tt.want, got)
+		}
+	}
+}
+
+func TestPad(t *testing.T) {
+	if got := PadRight("日本", 6); got != "日本  " {
+		t.Errorf("Expected %q, got %q", "日本  ", got)
+	}
+	if got := PadLeft("日本", 6); got != "  日本" {
+		t.Errorf("Expected %q, got %q", "  日本", got)
+	}
+	if got := PadLeft("testing", 3); got != "testing" {
+		t.Errorf("Expected %q, got %q", "testing", got)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	test := []struct {
+		val   string
+		width int
+		wrap  func(string, int) []string
+		want  []string
+	}{
+		{"", 5, WrapHard, []string{""}},
+		{"abcdefgh", 3, WrapHard, []string{"abc", "def", "gh"}},
+		{"日本語です", 5, WrapHard, []string{"日本", "語で", "す"}},
+		{"ab\ncd\r\nef", 5, WrapHard, []string{"ab", "cd", "ef"}},
+		{"the quick brown fox", 10, WrapWords, []string{"the quick", "brown fox"}},
+		{"the quick brown fox", 3, WrapWords, []string{"the", "qui", "ck", "bro", "wn", "fox"}},
+		{"one two\n\nthree", 20, WrapWords, []string{"one two", "", "three"}},
+		{"日本 日本語", 4, WrapWords, []string{"日本", "日本", "語"}},
+	}
+	for _, tt := range test {
+		if got := tt.wrap(tt.val, tt.width); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("(%q,%d): expected %q, got %q", tt.val, tt.width, tt.want, got)
+		}
+	}
+}
+
+func TestEscapes(t *testing.T) {
+	widths := map[string]int{
+		"\x1b[31mred\x1b[0m":                   3,
+		"\x1b[1;4;38;5;196m日本\x1b[0m":          4,
+		"\x1b]8;;http://x\x1b\\link\x1b]8;;\a": 4,
+		"\x1b[":                                1,
+		"a\x1b[0mé":                           2,
+	}
+	for s, want := range widths {
+		if got := String(s); got != want {
+			t.Errorf("String(%q): expected %d, got %d", s, want, got)
+		}
+	}
+	if got, want := Graphemes("a\x1b[31mb"), []string{"a", "\x1b[31m", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Graphemes: expected %q, got %q", want, got)
+	}
+	if got, want := Strip("\x1b[31mred\x1b[0m \x1b]0;title\a!"), "red !"; got != want {
+		t.Errorf("Strip: expected %q, got %q", want, got)
+	}
+
+	test := []struct {
+		val    string
+		width  int
+		mode   func(string, int, string) string
+		marker string
+		want   string
+	}{
+		{"\x1b[31mtesting\x1b[0m", 6, Truncate, "..", "\x1b[31mtest..\x1b[0m"},
+		{"\x1b[31mtesting\x1b[0m", 2, Truncate, "", "\x1b[31mte\x1b[0m"},
+		{"\x1b[31mtesting\x1b[0m", 6, TruncateStart, "..", "\x1b[31m..ting\x1b[0m"},
+		{"ab\x1b[1mcdef\x1b[0mgh", 6, TruncateMiddle, "..", "ab\x1b[1m..\x1b[0mgh"},
+	}
+	for _, tt := range test {
+		if got := tt.mode(tt.val, tt.width, tt.marker); got != tt.want {
+			t.Errorf("%q,%d,%q: expected %q, got %q", tt.val, tt.width, tt.marker, tt.want, got)
+		}
+	}
+}